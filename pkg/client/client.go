@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"reflect"
 	"time"
@@ -57,16 +59,52 @@ var (
 	DefaultNewClientFromRestConfig = NewClientFromRestConfig
 )
 
-func NewClient(token, apiEndpoint string) (runtimeclient.Client, error) {
-	return NewClientWithTransport(token, apiEndpoint, newTlsVerifySkippingTransport())
+func NewClient(ctx context.Context, token, apiEndpoint string) (runtimeclient.Client, error) {
+	return NewClientWithTransport(ctx, token, apiEndpoint, newTlsVerifySkippingTransport())
 }
 
-func NewClientFromRestConfig(config *rest.Config) (runtimeclient.Client, error) {
+func NewClientFromRestConfig(ctx context.Context, config *rest.Config) (runtimeclient.Client, error) {
 	config.Insecure = true
-	return newClientFromRestConfig(config)
+	return newClientFromRestConfig(ctx, config, NewClientRetries)
 }
 
-func NewClientWithTransport(token, apiEndpoint string, transport http.RoundTripper) (runtimeclient.Client, error) {
+func NewClientWithTransport(ctx context.Context, token, apiEndpoint string, transport http.RoundTripper) (runtimeclient.Client, error) {
+	cfg, err := newRestConfig(token, apiEndpoint, transport)
+	if err != nil {
+		return nil, err
+	}
+	return newClientFromRestConfig(ctx, cfg, NewClientRetries)
+}
+
+// NewClientWithContext creates a client like NewClient, but the client creation is not retried and is abandoned
+// as soon as the given context is done (eg, when its deadline is exceeded because the API server can't be reached)
+func NewClientWithContext(ctx context.Context, token, apiEndpoint string) (runtimeclient.Client, error) {
+	cfg, err := newRestConfig(token, apiEndpoint, newTlsVerifySkippingTransport())
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		cfg.Timeout = time.Until(deadline)
+	}
+	type result struct {
+		cl  runtimeclient.Client
+		err error
+	}
+	// the discovery done while creating the client does not support any context.Context
+	created := make(chan result, 1)
+	go func() {
+		cl, err := newClientFromRestConfig(ctx, cfg, 0)
+		created <- result{cl: cl, err: err}
+	}()
+	select {
+	case r := <-created:
+		return r.cl, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("cannot create client: %w", ctx.Err())
+	}
+}
+
+func newRestConfig(token, apiEndpoint string, transport http.RoundTripper) (*rest.Config, error) {
 	cfg, err := clientcmd.BuildConfigFromFlags(apiEndpoint, "")
 	if err != nil {
 		return nil, err
@@ -77,16 +115,41 @@ func NewClientWithTransport(token, apiEndpoint string, transport http.RoundTripp
 	cfg.QPS = 40.0
 	cfg.Burst = 50
 	cfg.Timeout = 60 * time.Second
-
-	return newClientFromRestConfig(cfg)
+	cfg.Impersonate = impersonationConfig()
+	return cfg, nil
 }
 
-func newClientFromRestConfig(cfg *rest.Config) (runtimeclient.Client, error) {
+func newClientFromRestConfig(ctx context.Context, cfg *rest.Config, retries int) (runtimeclient.Client, error) {
 	if err := AddToScheme(); err != nil {
 		return nil, err
 	}
+	if TraceRequests {
+		cfg.Wrap(newTracingRoundTripper)
+	}
 
-	cl, err := runtimeclient.New(cfg, runtimeclient.Options{})
+	var cl runtimeclient.Client
+	var err error
+	// the client creation connects to the API server (for the discovery), which may be briefly unreachable (eg, during a restart)
+	backoff := wait.Backoff{
+		Duration: NewClientRetryInterval,
+		Factor:   2,
+		Steps:    retries + 1,
+	}
+	if retryErr := wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
+		cl, err = runtimeclient.New(cfg, runtimeclient.Options{})
+		if err != nil && !isTransient(err) {
+			return false, err // retrying would not help (eg, when the token was rejected)
+		}
+		return err == nil, nil
+	}); retryErr != nil && !errors.Is(retryErr, wait.ErrWaitTimeout) {
+		err = retryErr
+	}
+	if apierrors.IsUnauthorized(err) {
+		// the expiry of the tokens which are not JWTs (eg, OpenShift 'sha256~' tokens) can't be checked when loading the config,
+		// so the first request (ie, the discovery) is the first place where it can be detected
+		return nil, fmt.Errorf("cannot create client: the token was rejected by the API server '%s', it has probably expired or been revoked, "+
+			"please refresh it (eg, with 'ksctl adm set-token'): %w", cfg.Host, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("cannot create client: %w", err)
 	}
@@ -94,6 +157,31 @@ func newClientFromRestConfig(cfg *rest.Config) (runtimeclient.Client, error) {
 	return cl, nil
 }
 
+// isTransient returns true if the given error may not occur again when retrying, ie, when the API server can't be reached
+// or is temporarily unable to serve the requests
+func isTransient(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err)
+}
+
+// NewClientRetries the number of times the client creation is retried when it fails because of a transient error
+// (eg, when the API server can't be reached), as long as the context of the command is not done
+var NewClientRetries = 2
+
+// NewClientRetryInterval the time to wait before the first retry of the client creation, which is doubled after each retry
+var NewClientRetryInterval = 500 * time.Millisecond
+
+// impersonationConfig returns the config to impersonate the user set with the `--impersonate` flag (if any)
+func impersonationConfig() rest.ImpersonationConfig {
+	return rest.ImpersonationConfig{
+		UserName: configuration.Impersonate,
+	}
+}
+
 func newTlsVerifySkippingTransport() http.RoundTripper {
 	return &http.Transport{
 		TLSClientConfig: &tls.Config{
@@ -113,6 +201,7 @@ func NewRESTClient(token, apiEndpoint string) (*rest.RESTClient, error) {
 		Host:        apiEndpoint,
 		Transport:   newTlsVerifySkippingTransport(),
 		Timeout:     60 * time.Second,
+		Impersonate: impersonationConfig(),
 		// These fields need to be set when using the REST client ¯\_(ツ)_/¯
 		ContentConfig: rest.ContentConfig{
 			GroupVersion:         &authv1.SchemeGroupVersion,
@@ -127,11 +216,12 @@ func PatchUserSignup(ctx *clicontext.CommandContext, name string, changeUserSign
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
 	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
 	if err != nil {
 		return err
 	}
-	userSignup, err := GetUserSignup(cl, cfg.OperatorNamespace, name)
+	userSignup, err := GetUserSignup(ctx, cl, cfg.OperatorNamespace, name)
 	if err != nil {
 		return err
 	}
@@ -139,7 +229,7 @@ func PatchUserSignup(ctx *clicontext.CommandContext, name string, changeUserSign
 	if shouldUpdate, err := changeUserSignup(patched); !shouldUpdate || err != nil {
 		return err
 	}
-	if err := cl.Patch(context.TODO(), patched, runtimeclient.MergeFrom(userSignup)); err != nil {
+	if err := cl.Patch(ctx, patched, runtimeclient.MergeFrom(userSignup)); err != nil {
 		return err
 	}
 
@@ -147,13 +237,13 @@ func PatchUserSignup(ctx *clicontext.CommandContext, name string, changeUserSign
 	return nil
 }
 
-func GetUserSignup(cl runtimeclient.Client, namespace, name string) (*toolchainv1alpha1.UserSignup, error) {
+func GetUserSignup(ctx context.Context, cl runtimeclient.Client, namespace, name string) (*toolchainv1alpha1.UserSignup, error) {
 	namespacedName := types.NamespacedName{
 		Namespace: namespace,
 		Name:      name,
 	}
 	userSignup := &toolchainv1alpha1.UserSignup{}
-	if err := cl.Get(context.TODO(), namespacedName, userSignup); err != nil {
+	if err := cl.Get(ctx, namespacedName, userSignup); err != nil {
 		return nil, err
 	}
 	return userSignup, nil
@@ -164,11 +254,12 @@ func PatchMasterUserRecord(ctx *clicontext.CommandContext, name string, changeMa
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
 	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
 	if err != nil {
 		return err
 	}
-	mur, err := GetMasterUserRecord(cl, cfg.OperatorNamespace, name)
+	mur, err := GetMasterUserRecord(ctx, cl, cfg.OperatorNamespace, name)
 	if err != nil {
 		return err
 	}
@@ -176,7 +267,7 @@ func PatchMasterUserRecord(ctx *clicontext.CommandContext, name string, changeMa
 	if shouldUpdate, err := changeMasterUserRecord(patched); !shouldUpdate || err != nil {
 		return err
 	}
-	if err := cl.Patch(context.TODO(), patched, runtimeclient.MergeFrom(mur)); err != nil {
+	if err := cl.Patch(ctx, patched, runtimeclient.MergeFrom(mur)); err != nil {
 		return err
 	}
 
@@ -184,13 +275,13 @@ func PatchMasterUserRecord(ctx *clicontext.CommandContext, name string, changeMa
 	return nil
 }
 
-func GetMasterUserRecord(cl runtimeclient.Client, namespace, name string) (*toolchainv1alpha1.MasterUserRecord, error) {
+func GetMasterUserRecord(ctx context.Context, cl runtimeclient.Client, namespace, name string) (*toolchainv1alpha1.MasterUserRecord, error) {
 	namespacedName := types.NamespacedName{
 		Namespace: namespace,
 		Name:      name,
 	}
 	obj := &toolchainv1alpha1.MasterUserRecord{}
-	if err := cl.Get(context.TODO(), namespacedName, obj); err != nil {
+	if err := cl.Get(ctx, namespacedName, obj); err != nil {
 		return nil, err
 	}
 	return obj, nil
@@ -201,11 +292,12 @@ func PatchSpace(ctx *clicontext.CommandContext, name string, changeSpace func(*t
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
 	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
 	if err != nil {
 		return err
 	}
-	space, err := GetSpace(cl, cfg.OperatorNamespace, name)
+	space, err := GetSpace(ctx, cl, cfg.OperatorNamespace, name)
 	if err != nil {
 		return err
 	}
@@ -213,7 +305,7 @@ func PatchSpace(ctx *clicontext.CommandContext, name string, changeSpace func(*t
 	if shouldUpdate, err := changeSpace(patched); !shouldUpdate || err != nil {
 		return err
 	}
-	if err := cl.Patch(context.TODO(), patched, runtimeclient.MergeFrom(space)); err != nil {
+	if err := cl.Patch(ctx, patched, runtimeclient.MergeFrom(space)); err != nil {
 		return err
 	}
 
@@ -221,18 +313,57 @@ func PatchSpace(ctx *clicontext.CommandContext, name string, changeSpace func(*t
 	return nil
 }
 
-func GetSpace(cl runtimeclient.Client, namespace, name string) (*toolchainv1alpha1.Space, error) {
+func GetSpace(ctx context.Context, cl runtimeclient.Client, namespace, name string) (*toolchainv1alpha1.Space, error) {
 	namespacedName := types.NamespacedName{
 		Namespace: namespace,
 		Name:      name,
 	}
 	obj := &toolchainv1alpha1.Space{}
-	if err := cl.Get(context.TODO(), namespacedName, obj); err != nil {
+	if err := cl.Get(ctx, namespacedName, obj); err != nil {
 		return nil, err
 	}
 	return obj, nil
 }
 
+// ListPageSize the maximum number of resources returned by a single request when listing resources page by page
+var ListPageSize int64 = 500
+
+// ListSpaces lists the Spaces in the given namespace page by page (see ListPageSize) and calls the given func
+// with the Spaces of each page, so the whole list is never loaded at once
+func ListSpaces(ctx context.Context, cl runtimeclient.Client, namespace string, onPage func([]toolchainv1alpha1.Space) error) error {
+	continueToken := ""
+	for {
+		spaces := &toolchainv1alpha1.SpaceList{}
+		if err := cl.List(ctx, spaces, runtimeclient.InNamespace(namespace), runtimeclient.Limit(ListPageSize), runtimeclient.Continue(continueToken)); err != nil {
+			return err
+		}
+		if err := onPage(spaces.Items); err != nil {
+			return err
+		}
+		if continueToken = spaces.Continue; continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// ListSpaceProvisionerConfigs lists the SpaceProvisionerConfigs in the given namespace page by page (see ListPageSize)
+// and calls the given func with the SpaceProvisionerConfigs of each page
+func ListSpaceProvisionerConfigs(ctx context.Context, cl runtimeclient.Client, namespace string, onPage func([]toolchainv1alpha1.SpaceProvisionerConfig) error) error {
+	continueToken := ""
+	for {
+		spcs := &toolchainv1alpha1.SpaceProvisionerConfigList{}
+		if err := cl.List(ctx, spcs, runtimeclient.InNamespace(namespace), runtimeclient.Limit(ListPageSize), runtimeclient.Continue(continueToken)); err != nil {
+			return err
+		}
+		if err := onPage(spcs.Items); err != nil {
+			return err
+		}
+		if continueToken = spcs.Continue; continueToken == "" {
+			return nil
+		}
+	}
+}
+
 type SpaceBindingMatchingLabel func(runtimeclient.MatchingLabels)
 
 func ForSpace(spaceName string) SpaceBindingMatchingLabel {
@@ -247,37 +378,37 @@ func ForMasterUserRecord(murName string) SpaceBindingMatchingLabel {
 	}
 }
 
-func ListSpaceBindings(cl runtimeclient.Client, namespace string, opts ...SpaceBindingMatchingLabel) ([]toolchainv1alpha1.SpaceBinding, error) {
+func ListSpaceBindings(ctx context.Context, cl runtimeclient.Client, namespace string, opts ...SpaceBindingMatchingLabel) ([]toolchainv1alpha1.SpaceBinding, error) {
 	spacebindings := &toolchainv1alpha1.SpaceBindingList{}
 	matchingLabels := runtimeclient.MatchingLabels{}
 	for _, apply := range opts {
 		apply(matchingLabels)
 	}
-	if err := cl.List(context.TODO(), spacebindings, runtimeclient.InNamespace(namespace), matchingLabels); err != nil {
+	if err := cl.List(ctx, spacebindings, runtimeclient.InNamespace(namespace), matchingLabels); err != nil {
 		return nil, err
 	}
 	return spacebindings.Items, nil
 }
 
-func GetNSTemplateTier(cfg configuration.ClusterConfig, cl runtimeclient.Client, name string) (*toolchainv1alpha1.NSTemplateTier, error) {
+func GetNSTemplateTier(ctx context.Context, cfg configuration.ClusterConfig, cl runtimeclient.Client, name string) (*toolchainv1alpha1.NSTemplateTier, error) {
 	namespacedName := types.NamespacedName{
 		Namespace: cfg.OperatorNamespace,
 		Name:      name,
 	}
 	obj := &toolchainv1alpha1.NSTemplateTier{}
-	if err := cl.Get(context.TODO(), namespacedName, obj); err != nil {
+	if err := cl.Get(ctx, namespacedName, obj); err != nil {
 		return nil, err
 	}
 	return obj, nil
 }
 
-func GetUserTier(cfg configuration.ClusterConfig, cl runtimeclient.Client, name string) (*toolchainv1alpha1.UserTier, error) {
+func GetUserTier(ctx context.Context, cfg configuration.ClusterConfig, cl runtimeclient.Client, name string) (*toolchainv1alpha1.UserTier, error) {
 	namespacedName := types.NamespacedName{
 		Namespace: cfg.OperatorNamespace,
 		Name:      name,
 	}
 	obj := &toolchainv1alpha1.UserTier{}
-	if err := cl.Get(context.TODO(), namespacedName, obj); err != nil {
+	if err := cl.Get(ctx, namespacedName, obj); err != nil {
 		return nil, err
 	}
 	return obj, nil
@@ -285,15 +416,15 @@ func GetUserTier(cfg configuration.ClusterConfig, cl runtimeclient.Client, name
 
 // Ensure creates or updates the given object and returns if the object was either created or updated (which means
 // that no error occurred and the administrator confirmed execution of the action)
-func Ensure(term ioutils.Terminal, cl runtimeclient.Client, obj runtimeclient.Object) (bool, error) {
+func Ensure(ctx context.Context, term ioutils.Terminal, cl runtimeclient.Client, obj runtimeclient.Object) (bool, error) {
 	namespacedName := types.NamespacedName{
 		Namespace: obj.GetNamespace(),
 		Name:      obj.GetName(),
 	}
-	return ensure(term, cl, namespacedName, obj)
+	return ensure(ctx, term, cl, namespacedName, obj)
 }
 
-func ensure(term ioutils.Terminal, cl runtimeclient.Client, namespacedName types.NamespacedName, obj runtimeclient.Object) (bool, error) {
+func ensure(ctx context.Context, term ioutils.Terminal, cl runtimeclient.Client, namespacedName types.NamespacedName, obj runtimeclient.Object) (bool, error) {
 	content, err := yaml.Marshal(obj)
 	if err != nil {
 		return false, err
@@ -306,7 +437,7 @@ func ensure(term ioutils.Terminal, cl runtimeclient.Client, namespacedName types
 	term.PrintContextSeparatorWithBodyf(string(content), "Using %s resource:", resourceKind)
 
 	existing := obj.DeepCopyObject().(runtimeclient.Object)
-	if err := cl.Get(context.TODO(), namespacedName, existing); err != nil && !apierrors.IsNotFound(err) {
+	if err := cl.Get(ctx, namespacedName, existing); err != nil && !apierrors.IsNotFound(err) {
 		return false, err
 	} else if err == nil {
 		term.Printlnf("There is an already existing %s with the same name: %s", resourceKind, namespacedName)
@@ -329,7 +460,7 @@ func ensure(term ioutils.Terminal, cl runtimeclient.Client, namespacedName types
 			return false, err
 		}
 
-		if err := cl.Update(context.TODO(), obj); err != nil {
+		if err := cl.Update(ctx, obj); err != nil {
 			return false, err
 		}
 		term.Printlnf("\nThe '%s' %s has been updated", namespacedName.Name, resourceKind)
@@ -339,7 +470,7 @@ func ensure(term ioutils.Terminal, cl runtimeclient.Client, namespacedName types
 	if !term.AskForConfirmation(ioutils.WithMessagef("create the %s resource with the name %s ?", resourceKind, namespacedName)) {
 		return false, nil
 	}
-	if err := cl.Create(context.TODO(), obj); err != nil {
+	if err := cl.Create(ctx, obj); err != nil {
 		return false, err
 	}
 	term.Printlnf("\nThe '%s' %s has been created", namespacedName, resourceKind)
@@ -347,16 +478,16 @@ func ensure(term ioutils.Terminal, cl runtimeclient.Client, namespacedName types
 }
 
 // Create creates the resource only if it does not exist yet (ie, if a resource of the same kind in the same namespace/name doesn't exist yet)
-func Create(term ioutils.Terminal, cl runtimeclient.Client, obj runtimeclient.Object) error {
+func Create(ctx context.Context, term ioutils.Terminal, cl runtimeclient.Client, obj runtimeclient.Object) error {
 	namespacedName := types.NamespacedName{
 		Namespace: obj.GetNamespace(),
 		Name:      obj.GetName(),
 	}
 	objCopy := obj.DeepCopyObject().(runtimeclient.Object)
-	if err := cl.Get(context.TODO(), namespacedName, objCopy); err != nil && !apierrors.IsNotFound(err) {
+	if err := cl.Get(ctx, namespacedName, objCopy); err != nil && !apierrors.IsNotFound(err) {
 		return err
 	} else if apierrors.IsNotFound(err) {
-		if err := cl.Create(context.TODO(), obj); err != nil {
+		if err := cl.Create(ctx, obj); err != nil {
 			return err
 		}
 		term.Printlnf("\nThe '%s' %s has been created", namespacedName, reflect.TypeOf(obj).Elem().Name())
@@ -369,11 +500,11 @@ func Create(term ioutils.Terminal, cl runtimeclient.Client, obj runtimeclient.Ob
 // GetRouteURL return the scheme+host of the route with the given namespaced name.
 // Since routes may take a bit of time to be available, this func uses a wait loop
 // to make sure that the route was created, or fails after a timeout.
-func GetRouteURL(term ioutils.Terminal, cl runtimeclient.Client, namespacedName types.NamespacedName) (string, error) {
+func GetRouteURL(ctx context.Context, term ioutils.Terminal, cl runtimeclient.Client, namespacedName types.NamespacedName) (string, error) {
 	term.Printlnf("Waiting for '%s' route to be available...", namespacedName.Name)
 	route := routev1.Route{}
-	if err := wait.Poll(retryInterval, timeout, func() (done bool, err error) {
-		if err := cl.Get(context.TODO(), namespacedName, &route); err != nil && !apierrors.IsNotFound(err) {
+	if err := wait.PollWithContext(ctx, retryInterval, timeout, func(ctx context.Context) (done bool, err error) {
+		if err := cl.Get(ctx, namespacedName, &route); err != nil && !apierrors.IsNotFound(err) {
 			return false, err
 		}
 		if len(route.Status.Ingress) == 0 {
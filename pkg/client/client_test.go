@@ -3,12 +3,20 @@ package client_test
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/codeready-toolchain/toolchain-common/pkg/states"
 	commontest "github.com/codeready-toolchain/toolchain-common/pkg/test"
 	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/configuration"
 	clicontext "github.com/kubesaw/ksctl/pkg/context"
 	. "github.com/kubesaw/ksctl/pkg/test"
 
@@ -18,6 +26,8 @@ import (
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,16 +43,250 @@ func TestNewClientOK(t *testing.T) {
 		BodyString("{}")
 
 	// when
-	cl, err := client.NewClientWithTransport("cool-token", "https://some-dummy-example.com", gock.DefaultTransport)
+	cl, err := client.NewClientWithTransport(context.TODO(), "cool-token", "https://some-dummy-example.com", gock.DefaultTransport)
 
 	// then
 	require.NoError(t, err)
 	assert.NotNil(t, cl)
 }
 
+func TestNewClientWithImpersonation(t *testing.T) {
+	// given
+	configuration.Impersonate = "system:serviceaccount:ksctl:reader"
+	t.Cleanup(func() {
+		configuration.Impersonate = ""
+	})
+	impersonatedUsers := []string{}
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		impersonatedUsers = append(impersonatedUsers, req.Header.Get("Impersonate-User"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader("{}")),
+			Request:    req,
+		}, nil
+	})
+
+	// when
+	cl, err := client.NewClientWithTransport(context.TODO(), "cool-token", "https://some-dummy-example.com", transport)
+	require.NoError(t, err)
+	_ = cl.List(context.TODO(), &toolchainv1alpha1.SpaceList{}) // the response does not matter, only the request does
+
+	// then
+	require.NotEmpty(t, impersonatedUsers)
+	for _, user := range impersonatedUsers {
+		assert.Equal(t, "system:serviceaccount:ksctl:reader", user)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClientWithRetries(t *testing.T) {
+	// given
+	client.NewClientRetryInterval = time.Millisecond
+	t.Cleanup(func() {
+		client.NewClientRetryInterval = 500 * time.Millisecond
+	})
+	newResponse := func(req *http.Request, statusCode int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}
+	}
+
+	t.Run("retries when the API server can't be reached", func(t *testing.T) {
+		// given
+		calls := 0
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls <= 2 {
+				return nil, fmt.Errorf("connection refused")
+			}
+			return newResponse(req, http.StatusOK, "{}"), nil
+		})
+
+		// when
+		cl, err := client.NewClientWithTransport(context.TODO(), "cool-token", "https://some-dummy-example.com", transport)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, cl)
+	})
+
+	t.Run("fails when the API server can't be reached after all retries", func(t *testing.T) {
+		// given
+		calls := 0
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return nil, fmt.Errorf("connection refused")
+		})
+
+		// when
+		cl, err := client.NewClientWithTransport(context.TODO(), "cool-token", "https://some-dummy-example.com", transport)
+
+		// then
+		require.ErrorContains(t, err, "connection refused")
+		assert.Nil(t, cl)
+		assert.Equal(t, client.NewClientRetries+1, calls)
+	})
+
+	t.Run("retries when the API server is unavailable", func(t *testing.T) {
+		// given
+		calls := 0
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls <= 2 {
+				return newResponse(req, http.StatusServiceUnavailable, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"ServiceUnavailable","code":503}`), nil
+			}
+			return newResponse(req, http.StatusOK, "{}"), nil
+		})
+
+		// when
+		cl, err := client.NewClientWithTransport(context.TODO(), "cool-token", "https://some-dummy-example.com", transport)
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, cl)
+	})
+
+	t.Run("does not retry on other errors", func(t *testing.T) {
+		// given
+		calls := 0
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return newResponse(req, http.StatusInternalServerError, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"InternalError","code":500}`), nil
+		})
+
+		// when
+		cl, err := client.NewClientWithTransport(context.TODO(), "cool-token", "https://some-dummy-example.com", transport)
+
+		// then
+		require.Error(t, err)
+		assert.Nil(t, cl)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops retrying when the context is done", func(t *testing.T) {
+		// given
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		calls := 0
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			cancel() // eg, the command was interrupted while the API server can't be reached
+			return nil, fmt.Errorf("connection refused")
+		})
+
+		// when
+		cl, err := client.NewClientWithTransport(ctx, "cool-token", "https://some-dummy-example.com", transport)
+
+		// then
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, cl)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("does not retry when unauthorized", func(t *testing.T) {
+		// given
+		calls := 0
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return newResponse(req, http.StatusUnauthorized, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`), nil
+		})
+
+		// when
+		cl, err := client.NewClientWithTransport(context.TODO(), "cool-token", "https://some-dummy-example.com", transport)
+
+		// then
+		require.ErrorContains(t, err, "the token was rejected by the API server 'https://some-dummy-example.com', it has probably expired or been revoked, "+
+			"please refresh it (eg, with 'ksctl adm set-token')")
+		assert.True(t, apierrors.IsUnauthorized(err))
+		assert.Nil(t, cl)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestNewClientWithContext(t *testing.T) {
+	t.Run("gives up when the context is done", func(t *testing.T) {
+		// given
+		var calls int32
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			<-release // the API server never answers (in time)
+		}))
+		defer server.Close()
+		defer close(release)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+
+		// when
+		cl, err := client.NewClientWithContext(ctx, "cool-token", server.URL)
+
+		// then
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, cl)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls)) // not retried
+	})
+}
+
+func TestTraceRequests(t *testing.T) {
+	// given
+	client.TraceRequests = true
+	trace := &strings.Builder{}
+	client.TraceOutput = trace
+	t.Cleanup(func() {
+		client.TraceRequests = false
+		client.TraceOutput = os.Stderr
+	})
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "Bearer cool-token", req.Header.Get("Authorization"))
+		body := "{}"
+		statusCode := http.StatusOK
+		switch req.URL.Path {
+		case "/api":
+			body = `{"kind":"APIVersions","versions":["v1"]}`
+		case "/api/v1":
+			body = `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"pods","singularName":"pod","namespaced":true,"kind":"Pod","verbs":["get"]}]}`
+		case "/apis":
+			body = `{"kind":"APIGroupList","groups":[]}`
+		case "/api/v1/namespaces/toolchain-host-operator/pods/cheesecake":
+			body = `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"cheesecake","namespace":"toolchain-host-operator"}}`
+		default:
+			statusCode = http.StatusNotFound
+			body = `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	cl, err := client.NewClientWithTransport(context.TODO(), "cool-token", "https://some-dummy-example.com", transport)
+	require.NoError(t, err)
+
+	// when
+	err = cl.Get(context.TODO(), types.NamespacedName{Namespace: "toolchain-host-operator", Name: "cheesecake"}, &corev1.Pod{})
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, trace.String(), "[trace] verb=get resource=/api namespace=- name=- status=200 latency=")
+	assert.Contains(t, trace.String(), "[trace] verb=get resource=pods/v1 namespace=toolchain-host-operator name=cheesecake status=200 latency=")
+	assert.NotContains(t, trace.String(), "cool-token")
+}
+
 func TestNewClientFail(t *testing.T) {
 	// when
-	cl, err := client.NewClient("cool-token", "https://fail-cluster.com")
+	cl, err := client.NewClient(context.TODO(), "cool-token", "https://fail-cluster.com")
 
 	// then
 	require.Error(t, err)
@@ -154,7 +398,7 @@ func TestPatchUserSignup(t *testing.T) {
 		userSignup := NewUserSignup()
 		fakeClient := commontest.NewFakeClient(t, userSignup)
 		term := NewFakeTerminal()
-		newClient := func(_, _ string) (runtimeclient.Client, error) {
+		newClient := func(_ context.Context, _, _ string) (runtimeclient.Client, error) {
 			return nil, fmt.Errorf("some error")
 		}
 		ctx := clicontext.NewCommandContext(term, newClient)
@@ -191,6 +435,107 @@ func TestUpdateUserSignupLacksPermissions(t *testing.T) {
 	AssertUserSignupSpec(t, fakeClient, userSignup)
 }
 
+func TestListSpaces(t *testing.T) {
+	// given
+	pages := map[string]*toolchainv1alpha1.SpaceList{
+		"": {
+			ListMeta: metav1.ListMeta{Continue: "page-2"},
+			Items:    []toolchainv1alpha1.Space{{ObjectMeta: metav1.ObjectMeta{Name: "john"}}, {ObjectMeta: metav1.ObjectMeta{Name: "jane"}}},
+		},
+		"page-2": {
+			Items: []toolchainv1alpha1.Space{{ObjectMeta: metav1.ObjectMeta{Name: "bob"}}},
+		},
+	}
+	fakeClient := commontest.NewFakeClient(t)
+	var limits []int64
+	fakeClient.MockList = func(ctx context.Context, list runtimeclient.ObjectList, opts ...runtimeclient.ListOption) error {
+		listOpts := &runtimeclient.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		assert.Equal(t, commontest.HostOperatorNs, listOpts.Namespace)
+		limits = append(limits, listOpts.Limit)
+		page, found := pages[listOpts.Continue]
+		if !found {
+			return fmt.Errorf("unexpected continue token '%s'", listOpts.Continue)
+		}
+		page.DeepCopyInto(list.(*toolchainv1alpha1.SpaceList))
+		return nil
+	}
+
+	t.Run("all pages are listed", func(t *testing.T) {
+		// given
+		limits = nil
+		var names []string
+
+		// when
+		err := client.ListSpaces(context.TODO(), fakeClient, commontest.HostOperatorNs, func(spaces []toolchainv1alpha1.Space) error {
+			for _, space := range spaces {
+				names = append(names, space.Name)
+			}
+			return nil
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"john", "jane", "bob"}, names)
+		assert.Equal(t, []int64{client.ListPageSize, client.ListPageSize}, limits)
+	})
+
+	t.Run("listing stops when page func fails", func(t *testing.T) {
+		// given
+		limits = nil
+
+		// when
+		err := client.ListSpaces(context.TODO(), fakeClient, commontest.HostOperatorNs, func(spaces []toolchainv1alpha1.Space) error {
+			return fmt.Errorf("page error")
+		})
+
+		// then
+		require.EqualError(t, err, "page error")
+		assert.Len(t, limits, 1)
+	})
+}
+
+func TestListSpaceProvisionerConfigs(t *testing.T) {
+	// given
+	pages := map[string]*toolchainv1alpha1.SpaceProvisionerConfigList{
+		"": {
+			ListMeta: metav1.ListMeta{Continue: "page-2"},
+			Items:    []toolchainv1alpha1.SpaceProvisionerConfig{{ObjectMeta: metav1.ObjectMeta{Name: "member-1"}}},
+		},
+		"page-2": {
+			Items: []toolchainv1alpha1.SpaceProvisionerConfig{{ObjectMeta: metav1.ObjectMeta{Name: "member-2"}}},
+		},
+	}
+	fakeClient := commontest.NewFakeClient(t)
+	var limits []int64
+	fakeClient.MockList = func(ctx context.Context, list runtimeclient.ObjectList, opts ...runtimeclient.ListOption) error {
+		listOpts := &runtimeclient.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		assert.Equal(t, commontest.HostOperatorNs, listOpts.Namespace)
+		limits = append(limits, listOpts.Limit)
+		page, found := pages[listOpts.Continue]
+		if !found {
+			return fmt.Errorf("unexpected continue token '%s'", listOpts.Continue)
+		}
+		page.DeepCopyInto(list.(*toolchainv1alpha1.SpaceProvisionerConfigList))
+		return nil
+	}
+	var names []string
+
+	// when
+	err := client.ListSpaceProvisionerConfigs(context.TODO(), fakeClient, commontest.HostOperatorNs, func(spcs []toolchainv1alpha1.SpaceProvisionerConfig) error {
+		for _, spc := range spcs {
+			names = append(names, spc.Name)
+		}
+		return nil
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"member-1", "member-2"}, names)
+	assert.Equal(t, []int64{client.ListPageSize, client.ListPageSize}, limits)
+}
+
 func TestEnsure(t *testing.T) {
 	// given
 	require.NoError(t, client.AddToScheme())
@@ -205,7 +550,7 @@ func TestEnsure(t *testing.T) {
 			actual := subs.DeepCopy()
 
 			// when
-			applied, err := client.Ensure(term, fakeClient, actual)
+			applied, err := client.Ensure(context.TODO(), term, fakeClient, actual)
 
 			// then
 			require.NoError(t, err)
@@ -226,7 +571,7 @@ func TestEnsure(t *testing.T) {
 
 			// when
 			actual := subs.DeepCopy()
-			applied, err := client.Ensure(term, fakeClient, actual)
+			applied, err := client.Ensure(context.TODO(), term, fakeClient, actual)
 
 			// then
 			require.NoError(t, err)
@@ -248,7 +593,7 @@ func TestEnsure(t *testing.T) {
 
 			// when
 			actual := subs.DeepCopy()
-			applied, err := client.Ensure(term, fakeClient, actual)
+			applied, err := client.Ensure(context.TODO(), term, fakeClient, actual)
 
 			// then
 			require.NoError(t, err)
@@ -276,7 +621,7 @@ func TestEnsure(t *testing.T) {
 
 			// when
 			actual := subs.DeepCopy()
-			applied, err := client.Ensure(term, fakeClient, actual)
+			applied, err := client.Ensure(context.TODO(), term, fakeClient, actual)
 
 			// then
 			require.Error(t, err)
@@ -301,7 +646,7 @@ func TestEnsure(t *testing.T) {
 
 			// when
 			actual := subs.DeepCopy()
-			applied, err := client.Ensure(term, fakeClient, actual)
+			applied, err := client.Ensure(context.TODO(), term, fakeClient, actual)
 
 			// then
 			require.Error(t, err)
@@ -326,7 +671,7 @@ func TestEnsure(t *testing.T) {
 
 			// when
 			actual := subs.DeepCopy()
-			applied, err := client.Ensure(term, fakeClient, actual)
+			applied, err := client.Ensure(context.TODO(), term, fakeClient, actual)
 
 			// then
 			require.Error(t, err)
@@ -354,7 +699,7 @@ func TestCreate(t *testing.T) {
 			operatorGroup := newOperatorGroup(namespacedName, map[string]string{"provider": "sandbox-sre"})
 
 			// when
-			err := client.Create(term, fakeClient, operatorGroup)
+			err := client.Create(context.TODO(), term, fakeClient, operatorGroup)
 
 			// then
 			require.NoError(t, err)
@@ -374,7 +719,7 @@ func TestCreate(t *testing.T) {
 			operatorGroup := newOperatorGroup(namespacedName, map[string]string{"provider": "sandbox-sre"})
 
 			// when
-			err := client.Create(term, fakeClient, operatorGroup)
+			err := client.Create(context.TODO(), term, fakeClient, operatorGroup)
 
 			// then
 			require.NoError(t, err)
@@ -394,7 +739,7 @@ func TestCreate(t *testing.T) {
 			operatorGroup := newOperatorGroup(namespacedName, map[string]string{"provider": "sandbox-sre"})
 
 			// when
-			err := client.Create(term, fakeClient, operatorGroup)
+			err := client.Create(context.TODO(), term, fakeClient, operatorGroup)
 
 			// then
 			require.Error(t, err)
@@ -412,7 +757,7 @@ func TestCreate(t *testing.T) {
 			operatorGroup := newOperatorGroup(namespacedName, map[string]string{"provider": "sandbox-sre"})
 
 			// when
-			err := client.Create(term, fakeClient, operatorGroup)
+			err := client.Create(context.TODO(), term, fakeClient, operatorGroup)
 
 			// then
 			require.Error(t, err)
@@ -454,7 +799,7 @@ func TestGetRoute(t *testing.T) {
 			fakeClient := commontest.NewFakeClient(t, route)
 
 			// when
-			r, err := client.GetRouteURL(term, fakeClient, types.NamespacedName{
+			r, err := client.GetRouteURL(context.TODO(), term, fakeClient, types.NamespacedName{
 				Namespace: "openshift-monitoring",
 				Name:      "thanos-querier",
 			})
@@ -485,7 +830,7 @@ func TestGetRoute(t *testing.T) {
 			fakeClient := commontest.NewFakeClient(t, route)
 
 			// when
-			r, err := client.GetRouteURL(term, fakeClient, types.NamespacedName{
+			r, err := client.GetRouteURL(context.TODO(), term, fakeClient, types.NamespacedName{
 				Namespace: "openshift-monitoring",
 				Name:      "thanos-querier",
 			})
@@ -512,7 +857,7 @@ func TestGetRoute(t *testing.T) {
 				return fmt.Errorf("mock error")
 			}
 			// when
-			_, err := client.GetRouteURL(term, fakeClient, types.NamespacedName{
+			_, err := client.GetRouteURL(context.TODO(), term, fakeClient, types.NamespacedName{
 				Namespace: "openshift-monitoring",
 				Name:      "thanos-querier",
 			})
@@ -535,7 +880,7 @@ func TestGetRoute(t *testing.T) {
 			fakeClient := commontest.NewFakeClient(t, route)
 
 			// when
-			_, err := client.GetRouteURL(term, fakeClient, types.NamespacedName{
+			_, err := client.GetRouteURL(context.TODO(), term, fakeClient, types.NamespacedName{
 				Namespace: "openshift-monitoring",
 				Name:      "thanos-querier",
 			})
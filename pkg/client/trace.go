@@ -0,0 +1,125 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+)
+
+// TraceRequests whether each request sent to the API servers is logged in TraceOutput (with the `--trace-requests` flag)
+var TraceRequests bool
+
+// TraceOutput where the traced requests are logged
+var TraceOutput io.Writer = os.Stderr
+
+// tracingRoundTripper logs the verb, the resource, the namespace, the name, the response status and the latency of each request.
+// The headers are never logged, so the bearer token does not appear in the trace.
+type tracingRoundTripper struct {
+	delegate http.RoundTripper
+}
+
+func newTracingRoundTripper(delegate http.RoundTripper) http.RoundTripper {
+	return &tracingRoundTripper{delegate: delegate}
+}
+
+func (t *tracingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.delegate.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+
+	attrs := requestAttributesOf(req)
+	status := ""
+	if err != nil {
+		status = fmt.Sprintf("error=%q", err.Error())
+	} else {
+		status = fmt.Sprintf("status=%d", resp.StatusCode)
+	}
+	// in case a secret ends up in the URL or in the error message
+	fmt.Fprintln(TraceOutput, ioutils.RedactSecrets(fmt.Sprintf("[trace] verb=%s resource=%s namespace=%s name=%s %s latency=%s",
+		attrs.verb, attrs.resource, valueOrDash(attrs.namespace), valueOrDash(attrs.name), status, latency)))
+	return resp, err
+}
+
+// requestAttributes the Kubernetes attributes of a request, as found in its method and URL
+type requestAttributes struct {
+	verb      string
+	resource  string // as <resource>[/<subresource>][.<group>]/<version>, or the URL path for non-resource requests (eg. discovery)
+	namespace string
+	name      string
+}
+
+// requestAttributesOf returns the attributes of the given request, parsing URL paths such as
+// `/api/<version>/namespaces/<namespace>/<resource>/<name>[/<subresource>]` or
+// `/apis/<group>/<version>/namespaces/<namespace>/<resource>/<name>[/<subresource>]`
+func requestAttributesOf(req *http.Request) requestAttributes {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	group := ""
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		segments = segments[1:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		group = segments[1]
+		segments = segments[2:]
+	default:
+		return requestAttributes{verb: strings.ToLower(req.Method), resource: req.URL.Path}
+	}
+	version := segments[0]
+	segments = segments[1:]
+	attrs := requestAttributes{}
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		attrs.namespace = segments[1]
+		segments = segments[2:]
+	}
+	resource := segments[0]
+	if len(segments) >= 2 {
+		attrs.name = segments[1]
+	}
+	if len(segments) >= 3 {
+		resource += "/" + strings.Join(segments[2:], "/")
+	}
+	if group != "" {
+		resource += "." + group
+	}
+	attrs.resource = resource + "/" + version
+	attrs.verb = kubeVerb(req, attrs.name)
+	return attrs
+}
+
+// kubeVerb returns the Kubernetes verb matching the method of the given request
+func kubeVerb(req *http.Request, name string) string {
+	switch req.Method {
+	case http.MethodGet:
+		if req.URL.Query().Get("watch") == "true" {
+			return "watch"
+		}
+		if name == "" {
+			return "list"
+		}
+		return "get"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut:
+		return "update"
+	case http.MethodPatch:
+		return "patch"
+	case http.MethodDelete:
+		if name == "" {
+			return "deletecollection"
+		}
+		return "delete"
+	default:
+		return strings.ToLower(req.Method)
+	}
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
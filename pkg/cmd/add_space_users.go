@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -28,13 +27,14 @@ one or more users specified by their MasterUserRecord name. One SpaceBinding wil
 		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 
 			return AddSpaceUsers(ctx, spaceName, role, users)
 		},
 	}
 	command.Flags().StringVarP(&spaceName, "space", "s", "", "the name of the space to add users to")
 	flags.MustMarkRequired(command, "space")
+	flags.MustRegisterCompletionFunc(command, "space", completeSpaceFlag)
 	command.Flags().StringVarP(&role, "role", "r", "", "the name of the role to assign to the users")
 	flags.MustMarkRequired(command, "role")
 	command.Flags().StringSliceVarP(&users, "users", "u", []string{}, "the masteruserrecord names of the users to add to the space delimited by comma")
@@ -48,6 +48,7 @@ func AddSpaceUsers(ctx *clicontext.CommandContext, spaceName, role string, users
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
 	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
 	if err != nil {
 		return err
@@ -55,13 +56,13 @@ func AddSpaceUsers(ctx *clicontext.CommandContext, spaceName, role string, users
 
 	// get Space
 	ctx.Println("Checking space...")
-	space, err := client.GetSpace(cl, cfg.OperatorNamespace, spaceName)
+	space, err := client.GetSpace(ctx, cl, cfg.OperatorNamespace, spaceName)
 	if err != nil {
 		return err
 	}
 
 	nsTemplTierName := space.Spec.TierName
-	nsTemplTier, err := client.GetNSTemplateTier(cfg, cl, nsTemplTierName)
+	nsTemplTier, err := client.GetNSTemplateTier(ctx, cfg, cl, nsTemplTierName)
 	if err != nil {
 		return err
 	}
@@ -85,7 +86,7 @@ func AddSpaceUsers(ctx *clicontext.CommandContext, spaceName, role string, users
 	ctx.Println("Checking users...")
 	spaceBindingsToCreate := []*toolchainv1alpha1.SpaceBinding{}
 	for _, murName := range usersToAdd {
-		mur, err := client.GetMasterUserRecord(cl, cfg.OperatorNamespace, murName)
+		mur, err := client.GetMasterUserRecord(ctx, cl, cfg.OperatorNamespace, murName)
 		if err != nil {
 			return err
 		}
@@ -105,7 +106,7 @@ func AddSpaceUsers(ctx *clicontext.CommandContext, spaceName, role string, users
 	ctx.Println("Creating SpaceBinding(s)...")
 	// create SpaceBindings
 	for _, sb := range spaceBindingsToCreate {
-		if err := cl.Create(context.TODO(), sb); err != nil {
+		if err := cl.Create(ctx, sb); err != nil {
 			return err
 		}
 	}
@@ -39,6 +39,7 @@ func TestAddSpaceUsers(t *testing.T) {
 		output := term.Output()
 		assertSpaceBindings(t, fakeClient, []string{"alice", "bob"}, "admin")
 		assert.Contains(t, output, "Are you sure that you want to add users to the above Space?")
+		assert.Contains(t, output, ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 		assert.Contains(t, output, "SpaceBinding(s) successfully created")
 		assert.NotContains(t, output, "cool-token")
 	})
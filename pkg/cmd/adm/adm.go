@@ -1,8 +1,6 @@
 package adm
 
 import (
-	"github.com/kubesaw/ksctl/pkg/ioutils"
-
 	"github.com/spf13/cobra"
 )
 
@@ -14,16 +12,17 @@ func NewAdmCmd() *cobra.Command {
 	}
 	registerCommands(admCommand)
 
-	admCommand.PersistentFlags().BoolVarP(&ioutils.AssumeYes, "assume-yes", "y", false, "Automatically answer yes for all questions.")
-
 	return admCommand
 }
 
 func registerCommands(admCommand *cobra.Command) {
 	// commands with go runtime client
 	admCommand.AddCommand(NewRestartCmd())
+	admCommand.AddCommand(NewGetDeploymentsCmd())
 	admCommand.AddCommand(NewUnregisterMemberCmd())
 	admCommand.AddCommand(NewMustGatherNamespaceCmd())
+	admCommand.AddCommand(NewCapacityReportCmd())
+	admCommand.AddCommand(NewSetTokenCmd())
 
 	// commands running external script
 	admCommand.AddCommand(NewRegisterMemberCmd())
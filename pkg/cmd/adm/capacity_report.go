@@ -0,0 +1,158 @@
+package adm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewCapacityReportCmd() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "capacity-report",
+		Short: "Reports the Space utilization of all member clusters",
+		Long: `Reports, for each member cluster configured in a SpaceProvisionerConfig, the number of provisioned Spaces
+compared to the configured capacity threshold, and recommends the member cluster where new Spaces should land.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return CapacityReport(ctx, output)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json")
+	return command
+}
+
+// MemberCapacity the Space utilization of a single member cluster
+type MemberCapacity struct {
+	ToolchainCluster   string  `json:"toolchainCluster"`
+	Enabled            bool    `json:"enabled"`
+	SpaceCount         int     `json:"spaceCount"`
+	MaxNumberOfSpaces  uint    `json:"maxNumberOfSpaces"`
+	UtilizationPercent float64 `json:"utilizationPercent"`
+}
+
+// hasCapacity returns true if the member cluster is enabled and is still below its max number of Spaces (if any)
+func (c MemberCapacity) hasCapacity() bool {
+	return c.Enabled && (c.MaxNumberOfSpaces == 0 || uint(c.SpaceCount) < c.MaxNumberOfSpaces)
+}
+
+// CapacityReportResult the Space utilization of all member clusters along with the recommended one for new Spaces
+type CapacityReportResult struct {
+	Members     []MemberCapacity `json:"members"`
+	Recommended string           `json:"recommended,omitempty"`
+}
+
+func CapacityReport(ctx *clicontext.CommandContext, output string) error {
+	if err := ioutils.ValidateOutputFormat(output); err != nil {
+		return err
+	}
+	cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
+	if err != nil {
+		return err
+	}
+	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
+	if err != nil {
+		return err
+	}
+	report, err := computeCapacityReport(ctx, cl, cfg.OperatorNamespace)
+	if err != nil {
+		return err
+	}
+	if output == "json" {
+		return ioutils.PrintJSON(ctx, report)
+	}
+	if len(report.Members) == 0 {
+		ctx.Printlnf("No SpaceProvisionerConfig found in the '%s' namespace", cfg.OperatorNamespace)
+		return nil
+	}
+	w := tabwriter.NewWriter(ctx.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tENABLED\tSPACES\tMAX SPACES\tUTILIZATION")
+	for _, m := range report.Members {
+		maxSpaces, utilization := "unlimited", "-"
+		if m.MaxNumberOfSpaces > 0 {
+			maxSpaces = fmt.Sprintf("%d", m.MaxNumberOfSpaces)
+			utilization = fmt.Sprintf("%.1f%%", m.UtilizationPercent)
+		}
+		fmt.Fprintf(w, "%s\t%t\t%d\t%s\t%s\n", m.ToolchainCluster, m.Enabled, m.SpaceCount, maxSpaces, utilization)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if report.Recommended == "" {
+		ctx.Println("\nThere is no enabled member cluster with free capacity for new Spaces")
+		return nil
+	}
+	ctx.Printlnf("\nNew Spaces should preferably land on the '%s' cluster", report.Recommended)
+	return nil
+}
+
+func computeCapacityReport(ctx context.Context, cl runtimeclient.Client, namespace string) (CapacityReportResult, error) {
+	var spcs []toolchainv1alpha1.SpaceProvisionerConfig
+	if err := client.ListSpaceProvisionerConfigs(ctx, cl, namespace, func(page []toolchainv1alpha1.SpaceProvisionerConfig) error {
+		spcs = append(spcs, page...)
+		return nil
+	}); err != nil {
+		return CapacityReportResult{}, err
+	}
+	// only the number of Spaces per cluster is kept, so the whole list of Spaces is never loaded at once
+	spaceCounts := map[string]int{}
+	if err := client.ListSpaces(ctx, cl, namespace, func(spaces []toolchainv1alpha1.Space) error {
+		for _, space := range spaces {
+			if space.Spec.TargetCluster != "" {
+				spaceCounts[space.Spec.TargetCluster]++
+			}
+		}
+		return nil
+	}); err != nil {
+		return CapacityReportResult{}, err
+	}
+
+	report := CapacityReportResult{
+		Members: []MemberCapacity{},
+	}
+	for _, spc := range spcs {
+		capacity := MemberCapacity{
+			ToolchainCluster:  spc.Spec.ToolchainCluster,
+			Enabled:           spc.Spec.Enabled,
+			SpaceCount:        spaceCounts[spc.Spec.ToolchainCluster],
+			MaxNumberOfSpaces: spc.Spec.CapacityThresholds.MaxNumberOfSpaces,
+		}
+		if capacity.MaxNumberOfSpaces > 0 {
+			capacity.UtilizationPercent = float64(capacity.SpaceCount) * 100 / float64(capacity.MaxNumberOfSpaces)
+		}
+		report.Members = append(report.Members, capacity)
+	}
+	sort.Slice(report.Members, func(i, j int) bool {
+		return report.Members[i].ToolchainCluster < report.Members[j].ToolchainCluster
+	})
+
+	// recommend the enabled cluster with the lowest utilization (clusters without any limit count as empty),
+	// and in case of a tie, the one with the lowest number of Spaces
+	var recommended *MemberCapacity
+	for i, m := range report.Members {
+		if !m.hasCapacity() {
+			continue
+		}
+		if recommended == nil ||
+			m.UtilizationPercent < recommended.UtilizationPercent ||
+			(m.UtilizationPercent == recommended.UtilizationPercent && m.SpaceCount < recommended.SpaceCount) {
+			recommended = &report.Members[i]
+		}
+	}
+	if recommended != nil {
+		report.Recommended = recommended.ToolchainCluster
+	}
+	return report, nil
+}
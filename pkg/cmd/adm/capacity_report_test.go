@@ -0,0 +1,160 @@
+package adm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/kubesaw/ksctl/pkg/client"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCapacityReport(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member())
+	objs := []runtime.Object{
+		newSpaceProvisionerConfig("member-1", true, 10),
+		newSpaceProvisionerConfig("member-2", true, 4),
+	}
+	objs = append(objs, newSpaces("member-1", 5)...)
+	objs = append(objs, newSpaces("member-2", 1)...)
+
+	t.Run("as table", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, objs...)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := CapacityReport(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "member-1   true      5        10           50.0%")
+		assert.Contains(t, output, "member-2   true      1        4            25.0%")
+		assert.Contains(t, output, "New Spaces should preferably land on the 'member-2' cluster")
+		assert.NotContains(t, output, "cool-token")
+	})
+
+	t.Run("as json", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, objs...)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := CapacityReport(ctx, "json")
+
+		// then
+		require.NoError(t, err)
+		report := CapacityReportResult{}
+		require.NoError(t, json.Unmarshal([]byte(term.Output()), &report))
+		assert.Equal(t, CapacityReportResult{
+			Members: []MemberCapacity{
+				{ToolchainCluster: "member-1", Enabled: true, SpaceCount: 5, MaxNumberOfSpaces: 10, UtilizationPercent: 50},
+				{ToolchainCluster: "member-2", Enabled: true, SpaceCount: 1, MaxNumberOfSpaces: 4, UtilizationPercent: 25},
+			},
+			Recommended: "member-2",
+		}, report)
+	})
+
+	t.Run("lists page by page", func(t *testing.T) {
+		// given
+		newClient, fakeClient := NewFakeClients(t, objs...)
+		var limits []int64
+		fakeClient.MockList = func(ctx context.Context, list runtimeclient.ObjectList, opts ...runtimeclient.ListOption) error {
+			listOpts := &runtimeclient.ListOptions{}
+			listOpts.ApplyOptions(opts)
+			limits = append(limits, listOpts.Limit)
+			return fakeClient.Client.List(ctx, list, opts...)
+		}
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := CapacityReport(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []int64{client.ListPageSize, client.ListPageSize}, limits)
+		assert.Contains(t, term.Output(), "member-1   true      5        10           50.0%")
+	})
+
+	t.Run("disabled and full clusters are not recommended", func(t *testing.T) {
+		// given
+		objs := []runtime.Object{
+			newSpaceProvisionerConfig("member-1", true, 2),
+			newSpaceProvisionerConfig("member-2", false, 0),
+		}
+		objs = append(objs, newSpaces("member-1", 2)...)
+		newClient, _ := NewFakeClients(t, objs...)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := CapacityReport(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "member-1   true      2        2            100.0%")
+		assert.Contains(t, output, "member-2   false     0        unlimited    -")
+		assert.Contains(t, output, "There is no enabled member cluster with free capacity for new Spaces")
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, objs...)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := CapacityReport(ctx, "yaml")
+
+		// then
+		require.EqualError(t, err, "unsupported output format 'yaml', the only supported format is 'json'")
+	})
+}
+
+func newSpaceProvisionerConfig(toolchainCluster string, enabled bool, maxNumberOfSpaces uint) *toolchainv1alpha1.SpaceProvisionerConfig {
+	return &toolchainv1alpha1.SpaceProvisionerConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      toolchainCluster,
+			Namespace: test.HostOperatorNs,
+		},
+		Spec: toolchainv1alpha1.SpaceProvisionerConfigSpec{
+			ToolchainCluster: toolchainCluster,
+			Enabled:          enabled,
+			CapacityThresholds: toolchainv1alpha1.SpaceProvisionerCapacityThresholds{
+				MaxNumberOfSpaces: maxNumberOfSpaces,
+			},
+		},
+	}
+}
+
+func newSpaces(targetCluster string, count int) []runtime.Object {
+	spaces := make([]runtime.Object, 0, count)
+	for i := 0; i < count; i++ {
+		spaces = append(spaces, &toolchainv1alpha1.Space{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-space-%d", targetCluster, i),
+				Namespace: test.HostOperatorNs,
+			},
+			Spec: toolchainv1alpha1.SpaceSpec{
+				TargetCluster: targetCluster,
+			},
+		})
+	}
+	return spaces
+}
@@ -0,0 +1,51 @@
+package adm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// waitForDeploymentRollout polls the status of the given deployment until its rollout is complete, ie, until all its replicas
+// are updated and available and no old replica remains. A progress line is printed every time the status changes.
+func waitForDeploymentRollout(ctx *clicontext.CommandContext, cl runtimeclient.Client, namespacedName types.NamespacedName, timeout time.Duration) error {
+	lastProgress := ""
+	return wait.PollWithContext(ctx, 500*time.Millisecond, timeout, func(context.Context) (done bool, err error) {
+		deployment := &appsv1.Deployment{}
+		if err := cl.Get(ctx, namespacedName, deployment); err != nil {
+			return false, err
+		}
+		progress, done := rolloutProgress(deployment)
+		if progress != lastProgress {
+			ctx.Println(progress)
+			lastProgress = progress
+		}
+		return done, nil
+	})
+}
+
+// rolloutProgress returns a message describing the progress of the rollout of the given deployment, and whether the rollout is complete
+func rolloutProgress(deployment *appsv1.Deployment) (string, bool) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return fmt.Sprintf("Waiting for the deployment '%s' spec update to be observed...", deployment.Name), false
+	}
+	replicas := deploymentReplicas(*deployment)
+	status := deployment.Status
+	if status.UpdatedReplicas < replicas {
+		return fmt.Sprintf("Waiting for the deployment '%s' rollout to finish: %d out of %d new replicas have been updated...", deployment.Name, status.UpdatedReplicas, replicas), false
+	}
+	if status.Replicas > status.UpdatedReplicas {
+		return fmt.Sprintf("Waiting for the deployment '%s' rollout to finish: %d old replicas are pending termination...", deployment.Name, status.Replicas-status.UpdatedReplicas), false
+	}
+	if status.AvailableReplicas < status.UpdatedReplicas {
+		return fmt.Sprintf("Waiting for the deployment '%s' rollout to finish: %d of %d updated replicas are available...", deployment.Name, status.AvailableReplicas, status.UpdatedReplicas), false
+	}
+	return fmt.Sprintf("The deployment '%s' was successfully rolled out", deployment.Name), true
+}
@@ -0,0 +1,119 @@
+package adm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRolloutProgress(t *testing.T) {
+	// given
+	namespacedName := types.NamespacedName{
+		Namespace: "toolchain-host-operator",
+		Name:      "cool-deployment",
+	}
+	tests := map[string]struct {
+		status   appsv1.DeploymentStatus
+		expected string
+		done     bool
+	}{
+		"spec update not observed yet": {
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 1},
+			expected: "Waiting for the deployment 'cool-deployment' spec update to be observed...",
+		},
+		"replicas not updated yet": {
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1},
+			expected: "Waiting for the deployment 'cool-deployment' rollout to finish: 1 out of 3 new replicas have been updated...",
+		},
+		"old replicas pending termination": {
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3},
+			expected: "Waiting for the deployment 'cool-deployment' rollout to finish: 1 old replicas are pending termination...",
+		},
+		"updated replicas not available yet": {
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2},
+			expected: "Waiting for the deployment 'cool-deployment' rollout to finish: 2 of 3 updated replicas are available...",
+		},
+		"rollout complete": {
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+			expected: "The deployment 'cool-deployment' was successfully rolled out",
+			done:     true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			deployment := newDeployment(namespacedName, 3)
+			deployment.Generation = 2
+			deployment.Status = tc.status
+
+			// when
+			progress, done := rolloutProgress(deployment)
+
+			// then
+			assert.Equal(t, tc.expected, progress)
+			assert.Equal(t, tc.done, done)
+		})
+	}
+}
+
+func TestWaitForDeploymentRollout(t *testing.T) {
+	// given
+	namespacedName := types.NamespacedName{
+		Namespace: "toolchain-host-operator",
+		Name:      "cool-deployment",
+	}
+
+	t.Run("waits until the rollout is complete", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 2)
+		deployment.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1}
+		newClient, fakeClient := NewFakeClients(t, deployment)
+		numberOfGetCalls := 0
+		fakeClient.MockGet = func(ctx context.Context, key runtimeclient.ObjectKey, obj runtimeclient.Object, opts ...runtimeclient.GetOption) error {
+			if err := fakeClient.Client.Get(ctx, key, obj, opts...); err != nil {
+				return err
+			}
+			numberOfGetCalls++
+			if d, ok := obj.(*appsv1.Deployment); ok && numberOfGetCalls > 1 {
+				d.Status.AvailableReplicas = 2 // the second replica becomes available
+			}
+			return nil
+		}
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := waitForDeploymentRollout(ctx, fakeClient, namespacedName, 5*time.Second)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 2, numberOfGetCalls)
+		assert.Equal(t, "Waiting for the deployment 'cool-deployment' rollout to finish: 1 of 2 updated replicas are available...\n"+
+			"The deployment 'cool-deployment' was successfully rolled out\n", term.Output())
+	})
+
+	t.Run("times out when the rollout does not finish", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 2)
+		newClient, fakeClient := NewFakeClients(t, deployment)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := waitForDeploymentRollout(ctx, fakeClient, namespacedName, time.Second)
+
+		// then
+		require.ErrorIs(t, err, wait.ErrWaitTimeout)
+		// the same progress is printed only once
+		assert.Equal(t, "Waiting for the deployment 'cool-deployment' rollout to finish: 0 out of 2 new replicas have been updated...\n", term.Output())
+	})
+}
@@ -0,0 +1,193 @@
+package adm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/cmd/flags"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// olmOwnerLabel the label set by OLM on the deployments it manages
+	olmOwnerLabel = "olm.owner"
+	// providerLabel the label set on the deployments of the toolchain operators which are not managed by OLM
+	providerLabel = "provider"
+	// providerLabelValue the value of the provider label of the toolchain operators
+	providerLabelValue = "codeready-toolchain"
+)
+
+func NewGetDeploymentsCmd() *cobra.Command {
+	var targetCluster string
+	var output string
+	command := &cobra.Command{
+		Use:   "get-deployments -t <cluster-name>",
+		Short: "Lists the operator deployments",
+		Long: `Lists the deployments in the operator namespace of the given cluster along with their ready replicas,
+separating the deployments managed by OLM from the other ones (with the 'provider=codeready-toolchain' label).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return GetDeployments(ctx, targetCluster, output)
+		},
+	}
+	command.Flags().StringVarP(&targetCluster, "target-cluster", "t", "", "The target cluster")
+	flags.MustMarkRequired(command, "target-cluster")
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json, custom-columns=<column>[,<column>...] (with the columns: "+strings.Join(deploymentColumns, ", ")+")")
+	return command
+}
+
+// deploymentColumns the columns which can be selected with the `-o custom-columns=...` output format
+var deploymentColumns = []string{"name", "ready", "replicas", "ready-replicas", "managed-by"}
+
+// DeploymentSummary the number of replicas of a single deployment
+type DeploymentSummary struct {
+	Name          string `json:"name"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+}
+
+// OperatorDeployments the deployments in the operator namespace, grouped by the way they are managed
+type OperatorDeployments struct {
+	Namespace string              `json:"namespace"`
+	OLM       []DeploymentSummary `json:"olm"`
+	NonOLM    []DeploymentSummary `json:"nonOLM"`
+}
+
+func GetDeployments(ctx *clicontext.CommandContext, clusterName, output string) error {
+	columns, err := ioutils.CustomColumns(output, deploymentColumns...)
+	if err != nil {
+		return err
+	}
+	if columns == nil {
+		if err := ioutils.ValidateOutputFormat(output); err != nil {
+			return err
+		}
+	}
+	cfg, err := configuration.LoadClusterConfig(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
+	if err != nil {
+		return err
+	}
+	deployments, err := getOperatorDeployments(ctx, cl, cfg.OperatorNamespace)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		return ioutils.PrintJSON(ctx, deployments)
+	}
+	if columns != nil {
+		return printDeploymentColumns(ctx, deployments, columns)
+	}
+	if err := printDeploymentSummaries(ctx, deployments.OLM, "OLM deployments in %s namespace", deployments.Namespace); err != nil {
+		return err
+	}
+	return printDeploymentSummaries(ctx, deployments.NonOLM, "Non-OLM deployments in %s namespace", deployments.Namespace)
+}
+
+func getOperatorDeployments(ctx context.Context, cl runtimeclient.Client, ns string) (OperatorDeployments, error) {
+	deployments := OperatorDeployments{
+		Namespace: ns,
+		OLM:       []DeploymentSummary{},
+		NonOLM:    []DeploymentSummary{},
+	}
+	olmDeployments := &appsv1.DeploymentList{}
+	if err := cl.List(ctx, olmDeployments,
+		runtimeclient.InNamespace(ns),
+		runtimeclient.HasLabels{olmOwnerLabel}); err != nil {
+		return deployments, err
+	}
+	for _, deployment := range olmDeployments.Items {
+		deployments.OLM = append(deployments.OLM, newDeploymentSummary(deployment))
+	}
+	providerDeployments := &appsv1.DeploymentList{}
+	if err := cl.List(ctx, providerDeployments,
+		runtimeclient.InNamespace(ns),
+		runtimeclient.MatchingLabels{providerLabel: providerLabelValue}); err != nil {
+		return deployments, err
+	}
+	for _, deployment := range providerDeployments.Items {
+		if _, ok := deployment.Labels[olmOwnerLabel]; ok {
+			continue // already listed along with the OLM deployments
+		}
+		deployments.NonOLM = append(deployments.NonOLM, newDeploymentSummary(deployment))
+	}
+	for _, summaries := range [][]DeploymentSummary{deployments.OLM, deployments.NonOLM} {
+		sort.Slice(summaries, func(i, j int) bool {
+			return summaries[i].Name < summaries[j].Name
+		})
+	}
+	return deployments, nil
+}
+
+func newDeploymentSummary(deployment appsv1.Deployment) DeploymentSummary {
+	return DeploymentSummary{
+		Name:          deployment.Name,
+		Replicas:      deploymentReplicas(deployment),
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+	}
+}
+
+// deploymentReplicas returns the desired number of replicas of the given deployment
+func deploymentReplicas(deployment appsv1.Deployment) int32 {
+	if deployment.Spec.Replicas == nil {
+		return 1 // the default number of replicas when not set
+	}
+	return *deployment.Spec.Replicas
+}
+
+// printDeploymentColumns prints the given columns of all the deployments, the OLM ones first
+func printDeploymentColumns(term ioutils.Terminal, deployments OperatorDeployments, columns []string) error {
+	summaries := append(append([]DeploymentSummary{}, deployments.OLM...), deployments.NonOLM...)
+	return ioutils.PrintColumns(term, columns, len(summaries), func(row int, column string) string {
+		s := summaries[row]
+		switch column {
+		case "ready":
+			return fmt.Sprintf("%d/%d", s.ReadyReplicas, s.Replicas)
+		case "replicas":
+			return fmt.Sprint(s.Replicas)
+		case "ready-replicas":
+			return fmt.Sprint(s.ReadyReplicas)
+		case "managed-by":
+			if row < len(deployments.OLM) {
+				return "olm"
+			}
+			return "other"
+		default:
+			return s.Name
+		}
+	})
+}
+
+func printDeploymentSummaries(term ioutils.Terminal, summaries []DeploymentSummary, title string, args ...interface{}) error {
+	if len(summaries) == 0 {
+		term.PrintContextSeparatorWithBodyf("No deployment found\n", title, args...)
+		return nil
+	}
+	deploymentList := &strings.Builder{}
+	w := tabwriter.NewWriter(deploymentList, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tREADY")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%d/%d\n", s.Name, s.ReadyReplicas, s.Replicas)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	term.PrintContextSeparatorWithBodyf(deploymentList.String(), title, args...)
+	return nil
+}
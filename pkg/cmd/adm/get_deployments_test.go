@@ -0,0 +1,125 @@
+package adm
+
+import (
+	"encoding/json"
+	"testing"
+
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGetDeployments(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member())
+	olmDeployment := newDeployment(types.NamespacedName{Namespace: "toolchain-host-operator", Name: "host-operator-controller-manager"}, 1)
+	olmDeployment.Labels = map[string]string{"olm.owner": "toolchain-host-operator.v0.0.1", "provider": "codeready-toolchain"}
+	olmDeployment.Status.ReadyReplicas = 1
+	nonOLMDeployment := newDeployment(types.NamespacedName{Namespace: "toolchain-host-operator", Name: "registration-service"}, 3)
+	nonOLMDeployment.Labels = map[string]string{"provider": "codeready-toolchain"}
+	nonOLMDeployment.Status.ReadyReplicas = 2
+	otherDeployment := newDeployment(types.NamespacedName{Namespace: "toolchain-host-operator", Name: "other"}, 1)
+	memberDeployment := newDeployment(types.NamespacedName{Namespace: "toolchain-member-operator", Name: "member-webhooks"}, 2)
+	memberDeployment.Labels = map[string]string{"provider": "codeready-toolchain"}
+
+	t.Run("as text", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, olmDeployment, nonOLMDeployment, otherDeployment, memberDeployment)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := GetDeployments(ctx, "host", "")
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "OLM deployments in toolchain-host-operator namespace")
+		assert.Contains(t, output, "host-operator-controller-manager   1/1")
+		assert.Contains(t, output, "Non-OLM deployments in toolchain-host-operator namespace")
+		assert.Contains(t, output, "registration-service   2/3")
+		assert.NotContains(t, output, "other")
+		assert.NotContains(t, output, "member-webhooks")
+		assert.NotContains(t, output, "cool-token")
+	})
+
+	t.Run("as json", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, olmDeployment, nonOLMDeployment, otherDeployment, memberDeployment)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := GetDeployments(ctx, "host", "json")
+
+		// then
+		require.NoError(t, err)
+		deployments := OperatorDeployments{}
+		require.NoError(t, json.Unmarshal([]byte(term.Output()), &deployments))
+		assert.Equal(t, OperatorDeployments{
+			Namespace: "toolchain-host-operator",
+			OLM:       []DeploymentSummary{{Name: "host-operator-controller-manager", Replicas: 1, ReadyReplicas: 1}},
+			NonOLM:    []DeploymentSummary{{Name: "registration-service", Replicas: 3, ReadyReplicas: 2}},
+		}, deployments)
+	})
+
+	t.Run("with custom columns", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, olmDeployment, nonOLMDeployment, otherDeployment, memberDeployment)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := GetDeployments(ctx, "host", "custom-columns=name,ready-replicas,managed-by")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "NAME                               READY-REPLICAS   MANAGED-BY\n"+
+			"host-operator-controller-manager   1                olm\n"+
+			"registration-service               2                other\n", term.Output())
+	})
+
+	t.Run("with unsupported custom column", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := GetDeployments(ctx, "host", "custom-columns=name,age")
+
+		// then
+		require.EqualError(t, err, "unsupported column 'age', the supported ones are: name, ready, replicas, ready-replicas, managed-by")
+	})
+
+	t.Run("when there is no deployment", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := GetDeployments(ctx, "member1", "")
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "OLM deployments in toolchain-member-operator namespace")
+		assert.Contains(t, term.Output(), "No deployment found")
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := GetDeployments(ctx, "host", "yaml")
+
+		// then
+		require.EqualError(t, err, "unsupported output format 'yaml', the only supported format is 'json'")
+	})
+}
@@ -49,7 +49,7 @@ func NewMustGatherNamespaceCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return MustGatherNamespace(term, kubeconfig, args[0], destDir)
+			return MustGatherNamespace(cmd.Context(), term, kubeconfig, args[0], destDir)
 		},
 	}
 	defaultKubeconfigPath := ""
@@ -63,7 +63,7 @@ func NewMustGatherNamespaceCmd() *cobra.Command {
 	return cmd
 }
 
-func MustGatherNamespace(term ioutils.Terminal, kubeconfig *restclient.Config, namespace, destDir string) error {
+func MustGatherNamespace(ctx context.Context, term ioutils.Terminal, kubeconfig *restclient.Config, namespace, destDir string) error {
 	// verify that the destDir exists, otherwise, create it
 
 	//  If path is already a directory, MkdirAll does nothing and returns nil.
@@ -125,7 +125,7 @@ func MustGatherNamespace(term ioutils.Terminal, kubeconfig *restclient.Config, n
 				Version: gv.Version,
 				Kind:    r.Kind,
 			})
-			if err := cl.List(context.Background(), list, runtimeclient.InNamespace(namespace)); err != nil {
+			if err := cl.List(ctx, list, runtimeclient.InNamespace(namespace)); err != nil {
 				// log the error but continue so we can collect the remaining resources
 				term.Printlnf("failed to list %s/%s: %v", strings.ToLower(gv.String()), strings.ToLower(r.Kind), err)
 				continue
@@ -151,7 +151,7 @@ func MustGatherNamespace(term ioutils.Terminal, kubeconfig *restclient.Config, n
 					}
 					for _, cs := range pod.Status.ContainerStatuses {
 						if cs.Started != nil && *cs.Started {
-							if err := gatherContainerLogs(term, rcl, destDir, namespace, pod.Name, cs.Name); err != nil {
+							if err := gatherContainerLogs(ctx, term, rcl, destDir, namespace, pod.Name, cs.Name); err != nil {
 								term.Printlnf("failed to collect logs from container '%s' in pod '%s': %v", cs.Name, pod.Name, err)
 								// ignore error, continue to next container
 								continue
@@ -165,10 +165,10 @@ func MustGatherNamespace(term ioutils.Terminal, kubeconfig *restclient.Config, n
 	return nil
 }
 
-func gatherContainerLogs(term ioutils.Terminal, rcl *restclient.RESTClient, destDir, namespace, podName, containerName string) error {
+func gatherContainerLogs(ctx context.Context, term ioutils.Terminal, rcl *restclient.RESTClient, destDir, namespace, podName, containerName string) error {
 	term.Printlnf("collecting logs from %s/%s", podName, containerName)
 	p := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/log", namespace, podName)
-	result := rcl.Get().AbsPath(p).Param("container", containerName).Do(context.Background())
+	result := rcl.Get().AbsPath(p).Param("container", containerName).Do(ctx)
 	if err := result.Error(); err != nil {
 		return err
 	}
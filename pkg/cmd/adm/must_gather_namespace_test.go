@@ -1,6 +1,7 @@
 package adm_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -41,7 +42,7 @@ func TestMustGatherNamespaceCmd(t *testing.T) {
 			destDir := filepath.Join(baseDir, "test-dev")
 
 			// when
-			err = adm.MustGatherNamespace(term, kubeconfig, "test-dev", destDir)
+			err = adm.MustGatherNamespace(context.TODO(), term, kubeconfig, "test-dev", destDir)
 
 			// then
 			require.NoError(t, err)
@@ -58,7 +59,7 @@ func TestMustGatherNamespaceCmd(t *testing.T) {
 			require.NoError(t, err)
 
 			// when
-			err = adm.MustGatherNamespace(term, kubeconfig, "test-dev", destDir)
+			err = adm.MustGatherNamespace(context.TODO(), term, kubeconfig, "test-dev", destDir)
 
 			// then
 			require.NoError(t, err)
@@ -81,7 +82,7 @@ func TestMustGatherNamespaceCmd(t *testing.T) {
 			require.NoError(t, err)
 
 			// when
-			err = adm.MustGatherNamespace(term, kubeconfig, "test-dev", destDir)
+			err = adm.MustGatherNamespace(context.TODO(), term, kubeconfig, "test-dev", destDir)
 
 			// then
 			require.NoError(t, err) // no error occurred, but command aborted
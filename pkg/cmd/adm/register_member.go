@@ -40,7 +40,7 @@ const (
 
 // newClientFromRestConfigFunc is a function to create a new Kubernetes client using the provided
 // rest configuration.
-type newClientFromRestConfigFunc func(*rest.Config) (runtimeclient.Client, error)
+type newClientFromRestConfigFunc func(context.Context, *rest.Config) (runtimeclient.Client, error)
 
 // This is an extended version of the CommandContext that is used specifically just in the register member command.
 type extendedCommandContext struct {
@@ -73,6 +73,7 @@ func NewRegisterMemberCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
 			ctx := newExtendedCommandContext(term, client.DefaultNewClientFromRestConfig)
+			ctx.CommandContext = ctx.WithContext(cmd.Context())
 			newCommand := func(name string, args ...string) *exec.Cmd {
 				return exec.Command(name, args...)
 			}
@@ -107,6 +108,10 @@ func registerMemberCluster(ctx *extendedCommandContext, newCommand client.Comman
 	if err != nil {
 		return err
 	}
+	// the ToolchainCluster resources are created in the host cluster first
+	hostCfg := configuration.ClusterConfig{ClusterName: configuration.HostName}
+	hostCfg.ServerAPI = data.hostApiEndpoint
+	ctx.SetTarget(hostCfg, args.hostNamespace)
 
 	validated, err := data.validate(ctx)
 	if err != nil {
@@ -274,7 +279,7 @@ func getApiEndpointAndClient(ctx *extendedCommandContext, kubeConfigPath string)
 	if err != nil {
 		return
 	}
-	cl, err = ctx.NewClientFromRestConfig(clientConfig)
+	cl, err = ctx.NewClientFromRestConfig(ctx, clientConfig)
 	if err != nil {
 		return
 	}
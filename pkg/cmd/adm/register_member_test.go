@@ -183,6 +183,7 @@ func TestRegisterMember(t *testing.T) {
 		// then
 		require.NoError(t, err)
 		assert.Equal(t, 2, *counter)
+		assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 		assert.Contains(t, term.Output(), "Modify and apply the following SpaceProvisionerConfig to the host cluster")
 		actualExampleSPC := extractExampleSPCFromOutput(t, term.Output())
 		assert.Equal(t, *expectedExampleSPC, actualExampleSPC)
@@ -704,7 +705,7 @@ func newFakeClientsFromRestConfig(t *testing.T, initObjs ...runtime.Object) (new
 	fakeClient.MockUpdate = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
 		return fakeClient.Client.Update(ctx, obj, opts...)
 	}
-	return func(cfg *rest.Config) (runtimeclient.Client, error) {
+	return func(_ context.Context, cfg *rest.Config) (runtimeclient.Client, error) {
 			assert.Contains(t, cfg.Host, "http")
 			assert.Contains(t, cfg.Host, "://")
 			assert.Contains(t, cfg.Host, ".com")
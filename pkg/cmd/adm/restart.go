@@ -2,7 +2,12 @@ package adm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/kubesaw/ksctl/pkg/client"
@@ -13,14 +18,33 @@ import (
 
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// defaultScaleBackTimeout the default time to wait for the deployment to be scaled back to its original number of replicas
+const defaultScaleBackTimeout = 10 * time.Second
+
+type restartFlags struct {
+	targetCluster     string
+	operatorNamespace string
+	timeout           time.Duration
+	dryRun            bool
+	onlyIfHealthy     bool
+	waitForPods       bool
+	waitForRollout    bool
+	outputFile        string
+	output            string
+	// errOut where the banner and the confirmation are printed with `--output json`, so only the report is printed in the standard output
+	errOut func() io.Writer
+}
+
 func NewRestartCmd() *cobra.Command {
-	var targetCluster string
+	f := restartFlags{}
 	command := &cobra.Command{
 		Use:   "restart -t <cluster-name> <deployment-name>",
 		Short: "Restarts a deployment",
@@ -29,17 +53,29 @@ If no deployment name is provided, then it lists all existing deployments in the
 		Args: cobra.RangeArgs(0, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
-			return restart(ctx, targetCluster, args...)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			f.errOut = cmd.ErrOrStderr
+			return restart(ctx, f, args...)
 		},
 	}
-	command.Flags().StringVarP(&targetCluster, "target-cluster", "t", "", "The target cluster")
+	command.Flags().StringVarP(&f.targetCluster, "target-cluster", "t", "", "The target cluster")
+	command.Flags().StringVar(&f.operatorNamespace, "operator-namespace", "", "The namespace of the deployment (default is the operator namespace of the target cluster)")
+	command.Flags().BoolVar(&f.dryRun, "dry-run", false, "Only print the deployment that would be restarted, without asking for confirmation and without restarting it")
+	command.Flags().BoolVar(&f.onlyIfHealthy, "only-if-healthy", false, "Abort the restart if the deployment does not have all its replicas ready")
+	command.Flags().BoolVar(&f.waitForPods, "wait-for-pods", false, "Wait until all the pods of the deployment are terminated before scaling it back")
+	command.Flags().BoolVar(&f.waitForRollout, "wait-for-rollout", false, "Wait until all the replicas of the deployment are updated and available after it was scaled back")
+	command.Flags().DurationVar(&f.timeout, "timeout", defaultScaleBackTimeout, "The maximum time to wait for the pods to be terminated (see '--wait-for-pods'), for the deployment to be scaled back to its original number of replicas and for its rollout to finish (see '--wait-for-rollout')")
+	command.Flags().StringVarP(&f.output, "output", "o", "", "Output format. One of: json (the report of the restart is printed instead of the progress messages, the banner and the confirmation being printed in the standard error)")
+	command.Flags().StringVar(&f.outputFile, "output-file", "", "The path of the file in which the actions taken during the restart are written as JSON, even if the restart fails")
 	flags.MustMarkRequired(command, "target-cluster")
 	return command
 }
 
-func restart(ctx *clicontext.CommandContext, clusterName string, deployments ...string) error {
-	cfg, err := configuration.LoadClusterConfig(ctx, clusterName)
+func restart(ctx *clicontext.CommandContext, f restartFlags, deployments ...string) error {
+	if err := ioutils.ValidateOutputFormat(f.output); err != nil {
+		return err
+	}
+	cfg, err := configuration.LoadClusterConfig(ctx, f.targetCluster)
 	if err != nil {
 		return err
 	}
@@ -47,9 +83,23 @@ func restart(ctx *clicontext.CommandContext, clusterName string, deployments ...
 	if err != nil {
 		return err
 	}
+	ns := cfg.OperatorNamespace
+	if f.operatorNamespace != "" {
+		ns = f.operatorNamespace
+	}
+	ctx.SetTarget(cfg, ns)
+	// with `--output json`, the standard output only contains the report
+	promptCtx := ctx
+	if f.output == "json" {
+		promptCtx = ctx.WithContext(ctx.Context)
+		promptCtx.Terminal = ioutils.NewTerminal(ctx.InOrStdin, f.errOutOrStderr)
+	}
 
 	if len(deployments) == 0 {
-		err := printExistingDeployments(ctx.Terminal, cl, cfg.OperatorNamespace)
+		if err := checkNamespaceExists(ctx, cl, ns, f.targetCluster); err != nil {
+			return err
+		}
+		err := printExistingDeployments(ctx, cl, ns)
 		if err != nil {
 			ctx.Terminal.Printlnf("\nERROR: Failed to list existing deployments\n :%s", err.Error())
 		}
@@ -57,92 +107,264 @@ func restart(ctx *clicontext.CommandContext, clusterName string, deployments ...
 	}
 	deploymentName := deployments[0]
 
-	if !ctx.AskForConfirmation(
-		ioutils.WithMessagef("restart the deployment '%s' in namespace '%s'", deploymentName, cfg.OperatorNamespace)) {
+	if f.dryRun {
+		return printDryRun(ctx, cl, ns, f.targetCluster, deploymentName)
+	}
+	var report *restartReport
+	if f.outputFile != "" || f.output == "json" {
+		report = newRestartReport(f.targetCluster, ns, deploymentName)
+	}
+	if !promptCtx.AskForConfirmation(
+		ioutils.WithMessagef("restart the deployment '%s' in namespace '%s'", deploymentName, ns)) {
+		report.record("confirmation", "the restart was declined")
+		return outputReport(ctx, report, f, nil)
+	}
+	restartCtx := ctx
+	if f.output == "json" {
+		restartCtx = ctx.WithContext(ctx.Context)
+		restartCtx.Terminal = quietTerminal{ctx.Terminal}
+	}
+	err = restartDeployment(restartCtx, cl, ns, deploymentName, f, report)
+	if reportErr := outputReport(ctx, report, f, err); reportErr != nil {
+		if err != nil {
+			ctx.Printlnf("ERROR: %s", reportErr.Error())
+			return err
+		}
+		return reportErr
+	}
+	return err
+}
+
+// errOutOrStderr returns the writer in which the banner and the confirmation are printed with `--output json`
+func (f restartFlags) errOutOrStderr() io.Writer {
+	if f.errOut == nil {
+		return os.Stderr
+	}
+	return f.errOut()
+}
+
+// outputReport completes the given report (if any) with the result of the restart, then writes it in the output file and/or prints it as JSON
+func outputReport(term ioutils.Terminal, report *restartReport, f restartFlags, restartErr error) error {
+	if report == nil {
 		return nil
 	}
-	return restartDeployment(ctx, cl, cfg.OperatorNamespace, deploymentName)
+	report.complete(restartErr)
+	if f.outputFile != "" {
+		if err := report.writeFile(f.outputFile); err != nil {
+			return err
+		}
+	}
+	if f.output == "json" {
+		return ioutils.PrintJSON(term, report)
+	}
+	return nil
 }
 
-func restartDeployment(ctx *clicontext.CommandContext, cl runtimeclient.Client, ns string, deploymentName string) error {
+func restartDeployment(ctx *clicontext.CommandContext, cl runtimeclient.Client, ns string, deploymentName string, f restartFlags, report *restartReport) error {
 	namespacedName := types.NamespacedName{
 		Namespace: ns,
 		Name:      deploymentName,
 	}
 
-	originalReplicas, err := scaleToZero(cl, namespacedName)
-	if err != nil {
+	if err := checkDeploymentHealth(ctx, cl, namespacedName, f.onlyIfHealthy, report); err != nil {
 		if apierrors.IsNotFound(err) {
-			ctx.Printlnf("\nERROR: The given deployment '%s' wasn't found.", deploymentName)
-			return printExistingDeployments(ctx, cl, ns)
+			report.record("health-check", "the deployment was not found")
+			return deploymentNotFound(ctx, cl, ns, f.targetCluster, deploymentName, err)
 		}
 		return err
 	}
+	originalReplicas, err := scaleToZero(ctx, cl, namespacedName)
+	if err != nil {
+		return err
+	}
+	report.record("scale-to-zero", "the deployment was scaled from %d to 0 replicas", originalReplicas)
 	ctx.Println("The deployment was scaled to 0")
-	if err := scaleBack(ctx, cl, namespacedName, originalReplicas); err != nil {
-		ctx.Printlnf("Scaling the deployment '%s' in namespace '%s' back to '%d' replicas wasn't successful", originalReplicas)
+	if f.waitForPods {
+		if err := waitForPodsToTerminate(ctx, cl, namespacedName, f.timeout); err != nil {
+			// the deployment still needs to be scaled back, so let's only warn about the pods
+			report.record("wait-for-pods", "the pods were not terminated: %s", err.Error())
+			ctx.Printlnf("WARNING: the pods of the deployment '%s' in namespace '%s' were not terminated within %s: %s", deploymentName, ns, f.timeout, err.Error())
+		} else {
+			report.record("wait-for-pods", "all the pods were terminated")
+			ctx.Println("All the pods of the deployment were terminated")
+		}
+	}
+	if err := scaleBack(ctx, cl, namespacedName, originalReplicas, f.timeout); err != nil {
+		report.record("scale-back", "the deployment was not scaled back to %d replicas: %s", originalReplicas, err.Error())
+		ctx.Printlnf("Scaling the deployment '%s' in namespace '%s' back to '%d' replicas wasn't successful", deploymentName, ns, originalReplicas)
 		ctx.Println("Please, try to contact administrators to scale the deployment back manually")
+		if errors.Is(err, wait.ErrWaitTimeout) {
+			return fmt.Errorf("the deployment '%s' in namespace '%s' was not scaled back to '%d' replicas within %s", deploymentName, ns, originalReplicas, f.timeout)
+		}
 		return err
 	}
 
+	report.record("scale-back", "the deployment was scaled back to %d replicas", originalReplicas)
 	ctx.Printlnf("The deployment was scaled back to '%d'", originalReplicas)
+	if f.waitForRollout {
+		if err := waitForDeploymentRollout(ctx, cl, namespacedName, f.timeout); err != nil {
+			report.record("rollout", "the rollout did not finish: %s", err.Error())
+			if errors.Is(err, wait.ErrWaitTimeout) {
+				return fmt.Errorf("the rollout of the deployment '%s' in namespace '%s' did not finish within %s", deploymentName, ns, f.timeout)
+			}
+			return err
+		}
+		report.record("rollout", "the rollout finished")
+	}
+	return nil
+}
+
+func printDryRun(ctx *clicontext.CommandContext, cl runtimeclient.Client, ns, clusterName, deploymentName string) error {
+	deployment := &appsv1.Deployment{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: ns, Name: deploymentName}, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return deploymentNotFound(ctx, cl, ns, clusterName, deploymentName, err)
+		}
+		return err
+	}
+	ctx.Printlnf("DRY RUN: the deployment '%s' in namespace '%s' would be scaled to 0 and then back to '%d' replicas", deploymentName, ns, deploymentReplicas(*deployment))
 	return nil
 }
 
 func restartHostOperator(ctx *clicontext.CommandContext, hostClient runtimeclient.Client, hostNamespace string) error {
 	deployments := &appsv1.DeploymentList{}
-	if err := hostClient.List(context.TODO(), deployments,
+	if err := hostClient.List(ctx, deployments,
 		runtimeclient.InNamespace(hostNamespace),
 		runtimeclient.MatchingLabels{"olm.owner.namespace": "toolchain-host-operator"}); err != nil {
 		return err
 	}
+	if configuration.Verbose {
+		ctx.Printlnf("Found %d deployment(s) matching the label olm.owner.namespace=toolchain-host-operator in %s ns: %s",
+			len(deployments.Items), hostNamespace, strings.Join(deploymentNames(deployments.Items), ", "))
+	}
 	if len(deployments.Items) != 1 {
 		return fmt.Errorf("there should be a single deployment matching the label olm.owner.namespace=toolchain-host-operator in %s ns, but %d was found. "+
 			"It's not possible to restart the Host Operator deployment", hostNamespace, len(deployments.Items))
 	}
 
-	return restartDeployment(ctx, hostClient, hostNamespace, deployments.Items[0].Name)
+	return restartDeployment(ctx, hostClient, hostNamespace, deployments.Items[0].Name, restartFlags{targetCluster: configuration.HostName, timeout: defaultScaleBackTimeout}, nil)
+}
+
+// checkDeploymentHealth prints the number of ready replicas of the deployment before it is restarted
+// and, if onlyIfHealthy is true, returns an error when not all the replicas are ready
+func checkDeploymentHealth(ctx *clicontext.CommandContext, cl runtimeclient.Client, namespacedName types.NamespacedName, onlyIfHealthy bool, report *restartReport) error {
+	deployment := &appsv1.Deployment{}
+	if err := cl.Get(ctx, namespacedName, deployment); err != nil {
+		return err
+	}
+	replicas := deploymentReplicas(*deployment)
+	report.record("health-check", "the deployment has %d/%d ready replicas", deployment.Status.ReadyReplicas, replicas)
+	ctx.Printlnf("The deployment '%s' in namespace '%s' has %d/%d ready replicas", namespacedName.Name, namespacedName.Namespace, deployment.Status.ReadyReplicas, replicas)
+	if onlyIfHealthy && deployment.Status.ReadyReplicas < replicas {
+		return fmt.Errorf("the deployment '%s' in namespace '%s' is not healthy (%d/%d ready replicas), so it was not restarted", namespacedName.Name, namespacedName.Namespace, deployment.Status.ReadyReplicas, replicas)
+	}
+	return nil
+}
+
+// deploymentNotFound returns an error if the namespace of the deployment does not exist (eg, when the operator namespace is misconfigured),
+// otherwise it prints the deployments which exist in the namespace and returns an error wrapping the given NotFound error
+func deploymentNotFound(ctx *clicontext.CommandContext, cl runtimeclient.Client, ns, clusterName, deploymentName string, notFoundErr error) error {
+	if err := checkNamespaceExists(ctx, cl, ns, clusterName); err != nil {
+		return err
+	}
+	ctx.Printlnf("\nERROR: The given deployment '%s' wasn't found.", deploymentName)
+	if err := printExistingDeployments(ctx, cl, ns); err != nil {
+		ctx.Printlnf("\nERROR: Failed to list existing deployments\n :%s", err.Error())
+	}
+	return fmt.Errorf("deployment '%s' not found in namespace '%s' on cluster '%s': %w", deploymentName, ns, clusterName, notFoundErr)
+}
+
+// checkNamespaceExists returns an error if the given namespace does not exist. Any other error (eg, when not allowed to get
+// the namespace) is ignored, since the namespace may still exist
+func checkNamespaceExists(ctx *clicontext.CommandContext, cl runtimeclient.Client, ns, clusterName string) error {
+	if err := cl.Get(ctx, types.NamespacedName{Name: ns}, &corev1.Namespace{}); err != nil && apierrors.IsNotFound(err) {
+		return fmt.Errorf("namespace '%s' not found on cluster '%s': %w", ns, clusterName, err)
+	}
+	return nil
 }
 
-func printExistingDeployments(term ioutils.Terminal, cl runtimeclient.Client, ns string) error {
+func deploymentNames(deployments []appsv1.Deployment) []string {
+	names := make([]string, 0, len(deployments))
+	for _, deployment := range deployments {
+		names = append(names, deployment.Name)
+	}
+	return names
+}
+
+func printExistingDeployments(ctx *clicontext.CommandContext, cl runtimeclient.Client, ns string) error {
 	deployments := &appsv1.DeploymentList{}
-	if err := cl.List(context.TODO(), deployments, runtimeclient.InNamespace(ns)); err != nil {
+	if err := cl.List(ctx, deployments, runtimeclient.InNamespace(ns)); err != nil {
 		return err
 	}
-	deploymentList := "\n"
+	deploymentList := &strings.Builder{}
+	w := tabwriter.NewWriter(deploymentList, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tREPLICAS\tREADY")
 	for _, deployment := range deployments.Items {
-		deploymentList += fmt.Sprintf("%s\n", deployment.Name)
+		replicas := deploymentReplicas(deployment)
+		fmt.Fprintf(w, "%s\t%d\t%d/%d\n", deployment.Name, replicas, deployment.Status.ReadyReplicas, replicas)
 	}
-	term.PrintContextSeparatorWithBodyf(deploymentList, "Existing deployments in %s namespace", ns)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	ctx.PrintContextSeparatorWithBodyf(deploymentList.String(), "Existing deployments in %s namespace", ns)
 	return nil
 }
 
-func scaleToZero(cl runtimeclient.Client, namespacedName types.NamespacedName) (int32, error) {
+func scaleToZero(ctx *clicontext.CommandContext, cl runtimeclient.Client, namespacedName types.NamespacedName) (int32, error) {
 	// get the deployment
 	deployment := &appsv1.Deployment{}
-	if err := cl.Get(context.TODO(), namespacedName, deployment); err != nil {
+	if err := cl.Get(ctx, namespacedName, deployment); err != nil {
 		return 0, err
 	}
 	// keep original number of replicas so we can bring it back
-	originalReplicas := *deployment.Spec.Replicas
+	originalReplicas := deploymentReplicas(*deployment)
+	if configuration.Verbose {
+		ctx.Printlnf("The deployment '%s' in namespace '%s' has '%d' replicas (generation: %d, ready replicas: %d)",
+			namespacedName.Name, namespacedName.Namespace, originalReplicas, deployment.Generation, deployment.Status.ReadyReplicas)
+	}
 	zero := int32(0)
 	deployment.Spec.Replicas = &zero
 
 	// update the deployment so it scales to zero
-	return originalReplicas, cl.Update(context.TODO(), deployment)
+	return originalReplicas, cl.Update(ctx, deployment)
+}
+
+// waitForPodsToTerminate waits until there is no pod matching the selector of the given deployment
+func waitForPodsToTerminate(ctx *clicontext.CommandContext, cl runtimeclient.Client, namespacedName types.NamespacedName, timeout time.Duration) error {
+	deployment := &appsv1.Deployment{}
+	if err := cl.Get(ctx, namespacedName, deployment); err != nil {
+		return err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return err
+	}
+	return wait.PollWithContext(ctx, 500*time.Millisecond, timeout, func(context.Context) (done bool, err error) {
+		pods := &corev1.PodList{}
+		if err := cl.List(ctx, pods, runtimeclient.InNamespace(namespacedName.Namespace), runtimeclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return false, err
+		}
+		if len(pods.Items) > 0 {
+			ctx.Printlnf("Waiting for %d pod(s) of the deployment to be terminated", len(pods.Items))
+			return false, nil
+		}
+		return true, nil
+	})
 }
 
-func scaleBack(term ioutils.Terminal, cl runtimeclient.Client, namespacedName types.NamespacedName, originalReplicas int32) error {
-	return wait.Poll(500*time.Millisecond, 10*time.Second, func() (done bool, err error) {
+// scaleBack sets the original number of replicas back. It deliberately does not use the context of the command,
+// so the deployment is not left scaled to zero when the command is interrupted (eg, with Ctrl-C)
+func scaleBack(term ioutils.Terminal, cl runtimeclient.Client, namespacedName types.NamespacedName, originalReplicas int32, timeout time.Duration) error {
+	return wait.Poll(500*time.Millisecond, timeout, func() (done bool, err error) {
 		term.Println("")
 		term.Printlnf("Trying to scale the deployment back to '%d'", originalReplicas)
-		// get the updated
+		// get the updated (with context.TODO(), see above: the command context may already be cancelled here)
 		deployment := &appsv1.Deployment{}
 		if err := cl.Get(context.TODO(), namespacedName, deployment); err != nil {
 			return false, err
 		}
 		// check if the replicas number wasn't already reset by a controller
-		if *deployment.Spec.Replicas == originalReplicas {
+		if deploymentReplicas(*deployment) == originalReplicas {
 			return true, nil
 		}
 		// set the original
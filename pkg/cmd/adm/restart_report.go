@@ -0,0 +1,90 @@
+package adm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	errs "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// restartReport the record of the actions taken while restarting a deployment, written as JSON in the file given
+// with the `--output-file` flag of the restart command and/or printed with the `--output json` flag
+type restartReport struct {
+	Cluster    string          `json:"cluster"`
+	Namespace  string          `json:"namespace"`
+	Deployment string          `json:"deployment"`
+	StartTime  time.Time       `json:"startTime"`
+	EndTime    time.Time       `json:"endTime"`
+	Duration   string          `json:"duration"`
+	Actions    []restartAction `json:"actions"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// restartAction a single action taken while restarting a deployment
+type restartAction struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Message string    `json:"message"`
+}
+
+func newRestartReport(clusterName, ns, deploymentName string) *restartReport {
+	return &restartReport{
+		Cluster:    clusterName,
+		Namespace:  ns,
+		Deployment: deploymentName,
+		StartTime:  time.Now(),
+		Actions:    []restartAction{},
+	}
+}
+
+// record adds the given action to the report. Does nothing if the report is nil (ie, no report was requested)
+func (r *restartReport) record(action, msg string, args ...interface{}) {
+	if r == nil {
+		return
+	}
+	r.Actions = append(r.Actions, restartAction{
+		Time:    time.Now(),
+		Action:  action,
+		Message: fmt.Sprintf(msg, args...),
+	})
+}
+
+// complete completes the report with the end time and the error of the restart (if any)
+func (r *restartReport) complete(restartErr error) {
+	r.EndTime = time.Now()
+	r.Duration = r.EndTime.Sub(r.StartTime).String()
+	if restartErr != nil {
+		r.Error = restartErr.Error()
+	}
+}
+
+// writeFile writes the report in the given file
+func (r *restartReport) writeFile(path string) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errs.Wrap(err, "unable to marshal the restart report")
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return errs.Wrapf(err, "unable to write the restart report in '%s'", path)
+	}
+	return nil
+}
+
+// quietTerminal a terminal which does not print any message, but still asks for the confirmations.
+// Used to print nothing else than the report with the `--output json` flag
+type quietTerminal struct {
+	ioutils.Terminal
+}
+
+func (t quietTerminal) Println(string)                                                {}
+func (t quietTerminal) Printlnf(string, ...interface{})                               {}
+func (t quietTerminal) PrintContextSeparatorf(string, ...interface{})                 {}
+func (t quietTerminal) PrintContextSeparatorWithBodyf(string, string, ...interface{}) {}
+func (t quietTerminal) PrintObject(runtime.Object, string) error {
+	return nil
+}
@@ -1,18 +1,27 @@
 package adm
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/codeready-toolchain/toolchain-common/pkg/test"
 	"github.com/kubesaw/ksctl/pkg/configuration"
 	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
 	. "github.com/kubesaw/ksctl/pkg/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,17 +52,40 @@ func TestRestartDeployment(t *testing.T) {
 			ctx := clicontext.NewCommandContext(term, newClient)
 
 			// when
-			err := restart(ctx, clusterName, "cool-deployment")
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout}, "cool-deployment")
 
 			// then
 			require.NoError(t, err)
 			AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
 			assert.Equal(t, 2, numberOfUpdateCalls)
+			assert.Contains(t, term.Output(), fmt.Sprintf(">>> target cluster: '%s' (cool-server.com), namespace: '%s'", clusterName, namespace))
+			assert.Contains(t, term.Output(), fmt.Sprintf("The deployment 'cool-deployment' in namespace '%s' has 0/3 ready replicas", namespace))
 		})
 
-		t.Run("list deployments when no deployment name is provided for "+clusterName, func(t *testing.T) {
+		t.Run("restart only if healthy is successful for "+clusterName, func(t *testing.T) {
+			// given
+			deployment := newDeployment(namespacedName, 3)
+			deployment.Status.ReadyReplicas = 3
+			newClient, fakeClient := NewFakeClients(t, deployment)
+			numberOfUpdateCalls := 0
+			fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+			term := NewFakeTerminalWithResponse("Y")
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout, onlyIfHealthy: true}, "cool-deployment")
+
+			// then
+			require.NoError(t, err)
+			AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+			assert.Equal(t, 2, numberOfUpdateCalls)
+			assert.Contains(t, term.Output(), fmt.Sprintf("The deployment 'cool-deployment' in namespace '%s' has 3/3 ready replicas", namespace))
+		})
+
+		t.Run("restart only if healthy fails - deployment is not healthy for "+clusterName, func(t *testing.T) {
 			// given
 			deployment := newDeployment(namespacedName, 3)
+			deployment.Status.ReadyReplicas = 1
 			newClient, fakeClient := NewFakeClients(t, deployment)
 			numberOfUpdateCalls := 0
 			fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
@@ -61,14 +93,35 @@ func TestRestartDeployment(t *testing.T) {
 			ctx := clicontext.NewCommandContext(term, newClient)
 
 			// when
-			err := restart(ctx, clusterName)
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout, onlyIfHealthy: true}, "cool-deployment")
+
+			// then
+			require.EqualError(t, err, fmt.Sprintf("the deployment 'cool-deployment' in namespace '%s' is not healthy (1/3 ready replicas), so it was not restarted", namespace))
+			AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+			assert.Equal(t, 0, numberOfUpdateCalls)
+			assert.Contains(t, term.Output(), fmt.Sprintf("The deployment 'cool-deployment' in namespace '%s' has 1/3 ready replicas", namespace))
+		})
+
+		t.Run("list deployments when no deployment name is provided for "+clusterName, func(t *testing.T) {
+			// given
+			deployment := newDeployment(namespacedName, 3)
+			deployment.Status.ReadyReplicas = 2
+			newClient, fakeClient := NewFakeClients(t, deployment, newNamespace(namespace))
+			numberOfUpdateCalls := 0
+			fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+			term := NewFakeTerminalWithResponse("Y")
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout})
 
 			// then
 			require.EqualError(t, err, "at least one deployment name is required, include one or more of the above deployments to restart")
 			AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
 			assert.Equal(t, 0, numberOfUpdateCalls)
 			assert.Contains(t, term.Output(), fmt.Sprintf("Existing deployments in toolchain-%s-operator namespace", clusterType))
-			assert.Contains(t, term.Output(), "cool-deployment")
+			assert.Contains(t, term.Output(), "NAME              REPLICAS   READY")
+			assert.Contains(t, term.Output(), "cool-deployment   3          2/3")
 		})
 
 		t.Run("restart fails - cannot get the deployment for "+clusterName, func(t *testing.T) {
@@ -83,7 +136,7 @@ func TestRestartDeployment(t *testing.T) {
 			ctx := clicontext.NewCommandContext(term, newClient)
 
 			// when
-			err := restart(ctx, clusterName, "cool-deployment")
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout}, "cool-deployment")
 
 			// then
 			require.Error(t, err)
@@ -92,27 +145,524 @@ func TestRestartDeployment(t *testing.T) {
 			assert.Equal(t, 0, numberOfUpdateCalls)
 		})
 
-		t.Run("restart fails - deployment not found for "+clusterName, func(t *testing.T) {
+		t.Run("restart fails - deployment is not scaled back in time for "+clusterName, func(t *testing.T) {
 			// given
 			deployment := newDeployment(namespacedName, 3)
 			newClient, fakeClient := NewFakeClients(t, deployment)
 			numberOfUpdateCalls := 0
-			fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+			fakeClient.MockUpdate = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
+				numberOfUpdateCalls++
+				if numberOfUpdateCalls > 1 {
+					return fmt.Errorf("some error")
+				}
+				return fakeClient.Client.Update(ctx, obj, opts...)
+			}
 			term := NewFakeTerminalWithResponse("Y")
 			ctx := clicontext.NewCommandContext(term, newClient)
 
 			// when
-			err := restart(ctx, clusterName, "wrong-deployment")
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: time.Second}, "cool-deployment")
+
+			// then
+			require.EqualError(t, err, fmt.Sprintf("the deployment 'cool-deployment' in namespace '%s' was not scaled back to '3' replicas within 1s", namespace))
+			AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 0)
+			assert.Contains(t, term.Output(), fmt.Sprintf("Scaling the deployment 'cool-deployment' in namespace '%s' back to '3' replicas wasn't successful", namespace))
+		})
+
+		t.Run("dry run does not restart the deployment for "+clusterName, func(t *testing.T) {
+			// given
+			deployment := newDeployment(namespacedName, 3)
+			newClient, fakeClient := NewFakeClients(t, deployment)
+			numberOfUpdateCalls := 0
+			fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+			term := NewFakeTerminalWithResponse("") // it should not read the input
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout, dryRun: true}, "cool-deployment")
 
 			// then
 			require.NoError(t, err)
 			AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
 			assert.Equal(t, 0, numberOfUpdateCalls)
+			assert.Contains(t, term.Output(), fmt.Sprintf("DRY RUN: the deployment 'cool-deployment' in namespace '%s' would be scaled to 0 and then back to '3' replicas", namespace))
+			assert.NotContains(t, term.Output(), "Are you sure")
+		})
+
+		t.Run("dry run with the default number of replicas for "+clusterName, func(t *testing.T) {
+			// given
+			deployment := newDeployment(namespacedName, 3)
+			deployment.Spec.Replicas = nil
+			newClient, _ := NewFakeClients(t, deployment)
+			term := NewFakeTerminalWithResponse("") // it should not read the input
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout, dryRun: true}, "cool-deployment")
+
+			// then
+			require.NoError(t, err)
+			assert.Contains(t, term.Output(), fmt.Sprintf("DRY RUN: the deployment 'cool-deployment' in namespace '%s' would be scaled to 0 and then back to '1' replicas", namespace))
+		})
+
+		t.Run("restart fails - deployment not found for "+clusterName, func(t *testing.T) {
+			// given
+			deployment := newDeployment(namespacedName, 3)
+			newClient, fakeClient := NewFakeClients(t, deployment, newNamespace(namespace))
+			numberOfUpdateCalls := 0
+			fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+			term := NewFakeTerminalWithResponse("Y")
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout}, "wrong-deployment")
+
+			// then
+			require.Error(t, err)
+			assert.True(t, apierrors.IsNotFound(err), "expected a NotFound error, got: %v", err)
+			assert.ErrorContains(t, err, fmt.Sprintf("deployment 'wrong-deployment' not found in namespace '%s' on cluster '%s'", namespace, clusterName))
+			AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+			assert.Equal(t, 0, numberOfUpdateCalls)
 			assert.Contains(t, term.Output(), "ERROR: The given deployment 'wrong-deployment' wasn't found.")
 			assert.Contains(t, term.Output(), fmt.Sprintf("Existing deployments in toolchain-%s-operator namespace", clusterType))
 			assert.Contains(t, term.Output(), "cool-deployment")
 		})
+
+		t.Run("restart fails - deployment not found with dry-run for "+clusterName, func(t *testing.T) {
+			// given
+			deployment := newDeployment(namespacedName, 3)
+			newClient, _ := NewFakeClients(t, deployment, newNamespace(namespace))
+			term := NewFakeTerminalWithResponse("") // it should not read the input
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout, dryRun: true}, "wrong-deployment")
+
+			// then
+			require.Error(t, err)
+			assert.True(t, apierrors.IsNotFound(err), "expected a NotFound error, got: %v", err)
+			assert.Contains(t, term.Output(), "ERROR: The given deployment 'wrong-deployment' wasn't found.")
+			assert.NotContains(t, term.Output(), "DRY RUN")
+		})
+
+		t.Run("restart fails - namespace not found for "+clusterName, func(t *testing.T) {
+			// given
+			newClient, fakeClient := NewFakeClients(t)
+			numberOfUpdateCalls := 0
+			fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+			term := NewFakeTerminalWithResponse("Y")
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout}, "cool-deployment")
+
+			// then
+			require.Error(t, err)
+			assert.True(t, apierrors.IsNotFound(err), "expected a NotFound error, got: %v", err)
+			assert.ErrorContains(t, err, fmt.Sprintf("namespace '%s' not found on cluster '%s'", namespace, clusterName))
+			assert.Equal(t, 0, numberOfUpdateCalls)
+			assert.NotContains(t, term.Output(), "wasn't found")
+		})
+	}
+}
+
+func TestRestartDeploymentInOperatorNamespace(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member())
+	namespacedName := types.NamespacedName{
+		Namespace: "custom-operator",
+		Name:      "cool-deployment",
+	}
+	deployment := newDeployment(namespacedName, 3)
+	newClient, fakeClient := NewFakeClients(t, deployment)
+	numberOfUpdateCalls := 0
+	fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+	term := NewFakeTerminalWithResponse("Y")
+	ctx := clicontext.NewCommandContext(term, newClient)
+
+	// when
+	err := restart(ctx, restartFlags{targetCluster: "host", operatorNamespace: "custom-operator", timeout: defaultScaleBackTimeout}, "cool-deployment")
+
+	// then
+	require.NoError(t, err)
+	AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+	assert.Equal(t, 2, numberOfUpdateCalls)
+	assert.Contains(t, term.Output(), "restart the deployment 'cool-deployment' in namespace 'custom-operator'")
+}
+
+func TestRestartDeploymentWithWaitForPods(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member())
+	namespacedName := types.NamespacedName{
+		Namespace: "toolchain-host-operator",
+		Name:      "cool-deployment",
+	}
+	newDeploymentWithPod := func() (*appsv1.Deployment, *corev1.Pod) {
+		deployment := newDeployment(namespacedName, 3)
+		deployment.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "cool"},
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespacedName.Namespace,
+				Name:      "cool-deployment-abcde",
+				Labels:    map[string]string{"app": "cool"},
+			},
+		}
+		return deployment, pod
+	}
+
+	t.Run("waits until the pods are terminated", func(t *testing.T) {
+		// given
+		deployment, pod := newDeploymentWithPod()
+		newClient, fakeClient := NewFakeClients(t, deployment, pod)
+		numberOfUpdateCalls := 0
+		fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+		numberOfPodListCalls := 0
+		fakeClient.MockList = func(ctx context.Context, list runtimeclient.ObjectList, opts ...runtimeclient.ListOption) error {
+			if _, ok := list.(*corev1.PodList); ok {
+				numberOfPodListCalls++
+				if numberOfPodListCalls == 2 {
+					// the pod is terminated after the first check
+					require.NoError(t, fakeClient.Client.Delete(ctx, pod))
+				}
+			}
+			return fakeClient.Client.List(ctx, list, opts...)
+		}
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, waitForPods: true}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+		assert.Equal(t, 2, numberOfUpdateCalls)
+		assert.Equal(t, 2, numberOfPodListCalls)
+		assert.Contains(t, term.Output(), "Waiting for 1 pod(s) of the deployment to be terminated")
+		assert.Contains(t, term.Output(), "All the pods of the deployment were terminated")
+	})
+
+	t.Run("scales back even when the pods are not terminated", func(t *testing.T) {
+		// given
+		deployment, pod := newDeploymentWithPod()
+		newClient, fakeClient := NewFakeClients(t, deployment, pod)
+		numberOfUpdateCalls := 0
+		fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: time.Second, waitForPods: true}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+		assert.Equal(t, 2, numberOfUpdateCalls)
+		assert.Contains(t, term.Output(), "WARNING: the pods of the deployment 'cool-deployment' in namespace 'toolchain-host-operator' were not terminated within 1s")
+	})
+
+	t.Run("stops waiting but scales back when interrupted", func(t *testing.T) {
+		// given
+		deployment, pod := newDeploymentWithPod()
+		newClient, fakeClient := NewFakeClients(t, deployment, pod)
+		numberOfUpdateCalls := 0
+		fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+		term := NewFakeTerminalWithResponse("Y")
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		ctx := clicontext.NewCommandContext(term, newClient).WithContext(cancelled)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, waitForPods: true}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+		assert.Equal(t, 2, numberOfUpdateCalls)
+		assert.Contains(t, term.Output(), "WARNING: the pods of the deployment 'cool-deployment' in namespace 'toolchain-host-operator' were not terminated")
+		assert.NotContains(t, term.Output(), "All the pods of the deployment were terminated")
+	})
+}
+
+func TestRestartDeploymentWithWaitForRollout(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member())
+	namespacedName := types.NamespacedName{
+		Namespace: "toolchain-host-operator",
+		Name:      "cool-deployment",
+	}
+
+	t.Run("waits until the rollout is complete", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 3)
+		deployment.Status = appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}
+		newClient, fakeClient := NewFakeClients(t, deployment)
+		numberOfUpdateCalls := 0
+		fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, waitForRollout: true}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+		assert.Equal(t, 2, numberOfUpdateCalls)
+		assert.Contains(t, term.Output(), "The deployment 'cool-deployment' was successfully rolled out")
+	})
+
+	t.Run("fails when the rollout does not finish in time", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 3)
+		newClient, fakeClient := NewFakeClients(t, deployment)
+		numberOfUpdateCalls := 0
+		fakeClient.MockUpdate = requireDeploymentBeingUpdated(t, fakeClient, namespacedName, 3, &numberOfUpdateCalls)
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: time.Second, waitForRollout: true}, "cool-deployment")
+
+		// then
+		require.EqualError(t, err, "the rollout of the deployment 'cool-deployment' in namespace 'toolchain-host-operator' did not finish within 1s")
+		AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 3)
+		assert.Equal(t, 2, numberOfUpdateCalls)
+	})
+}
+
+func TestRestartDeploymentWithOutputFile(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member())
+	namespacedName := types.NamespacedName{
+		Namespace: "toolchain-host-operator",
+		Name:      "cool-deployment",
+	}
+	readReport := func(t *testing.T, path string) restartReport {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		report := restartReport{}
+		require.NoError(t, json.Unmarshal(content, &report))
+		return report
+	}
+	actionsOf := func(report restartReport) []string {
+		actions := make([]string, 0, len(report.Actions))
+		for _, action := range report.Actions {
+			actions = append(actions, action.Action+": "+action.Message)
+		}
+		return actions
 	}
+
+	t.Run("when restart is successful", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 3)
+		deployment.Status.ReadyReplicas = 3
+		newClient, _ := NewFakeClients(t, deployment)
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+		outputFile := filepath.Join(t.TempDir(), "report.json")
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, outputFile: outputFile}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		report := readReport(t, outputFile)
+		assert.Equal(t, "host", report.Cluster)
+		assert.Equal(t, "toolchain-host-operator", report.Namespace)
+		assert.Equal(t, "cool-deployment", report.Deployment)
+		assert.Empty(t, report.Error)
+		assert.False(t, report.EndTime.Before(report.StartTime))
+		assert.Equal(t, []string{
+			"health-check: the deployment has 3/3 ready replicas",
+			"scale-to-zero: the deployment was scaled from 3 to 0 replicas",
+			"scale-back: the deployment was scaled back to 3 replicas",
+		}, actionsOf(report))
+	})
+
+	t.Run("when restart fails", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 3)
+		newClient, fakeClient := NewFakeClients(t, deployment)
+		numberOfUpdateCalls := 0
+		fakeClient.MockUpdate = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
+			numberOfUpdateCalls++
+			if numberOfUpdateCalls > 1 {
+				return fmt.Errorf("some error")
+			}
+			return fakeClient.Client.Update(ctx, obj, opts...)
+		}
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+		outputFile := filepath.Join(t.TempDir(), "report.json")
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: time.Second, outputFile: outputFile}, "cool-deployment")
+
+		// then
+		require.EqualError(t, err, "the deployment 'cool-deployment' in namespace 'toolchain-host-operator' was not scaled back to '3' replicas within 1s")
+		report := readReport(t, outputFile)
+		assert.Equal(t, err.Error(), report.Error)
+		assert.Equal(t, []string{
+			"health-check: the deployment has 0/3 ready replicas",
+			"scale-to-zero: the deployment was scaled from 3 to 0 replicas",
+			"scale-back: the deployment was not scaled back to 3 replicas: timed out waiting for the condition",
+		}, actionsOf(report))
+	})
+
+	t.Run("when restart is declined", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 3)
+		newClient, _ := NewFakeClients(t, deployment)
+		term := NewFakeTerminalWithResponse("n")
+		ctx := clicontext.NewCommandContext(term, newClient)
+		outputFile := filepath.Join(t.TempDir(), "report.json")
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, outputFile: outputFile}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		report := readReport(t, outputFile)
+		assert.Equal(t, []string{"confirmation: the restart was declined"}, actionsOf(report))
+	})
+}
+
+func TestRestartDeploymentWithJSONOutput(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member())
+	namespacedName := types.NamespacedName{
+		Namespace: "toolchain-host-operator",
+		Name:      "cool-deployment",
+	}
+	readReport := func(t *testing.T, output string) restartReport {
+		// the whole output is the report
+		report := restartReport{}
+		require.NoError(t, json.Unmarshal([]byte(output), &report), "the output is not a JSON report: %s", output)
+		return report
+	}
+
+	t.Run("when restart is successful", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 3)
+		deployment.Status.ReadyReplicas = 3
+		newClient, _ := NewFakeClients(t, deployment)
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+		errOut := &bytes.Buffer{}
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, output: "json", errOut: bufferWriter(errOut)}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		report := readReport(t, term.Output())
+		assert.Equal(t, "host", report.Cluster)
+		assert.Equal(t, "cool-deployment", report.Deployment)
+		assert.Empty(t, report.Error)
+		assert.Len(t, report.Actions, 3)
+		assert.NotContains(t, term.Output(), "The deployment was scaled to 0")
+		assert.NotContains(t, term.Output(), "cool-token")
+		// the banner and the confirmation are printed in the error output
+		assert.Contains(t, errOut.String(), ">>> target cluster: 'host'")
+		assert.Contains(t, errOut.String(), "Are you sure that you want to restart the deployment 'cool-deployment'")
+	})
+
+	t.Run("when restart is successful with assume-yes", func(t *testing.T) {
+		// given
+		ioutils.AssumeYes = true
+		t.Cleanup(func() {
+			ioutils.AssumeYes = false
+		})
+		deployment := newDeployment(namespacedName, 3)
+		deployment.Status.ReadyReplicas = 3
+		newClient, _ := NewFakeClients(t, deployment)
+		term := NewFakeTerminalWithResponse("n") // not read
+		ctx := clicontext.NewCommandContext(term, newClient)
+		errOut := &bytes.Buffer{}
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, output: "json", errOut: bufferWriter(errOut)}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		report := readReport(t, term.Output())
+		assert.Empty(t, report.Error)
+		assert.Contains(t, errOut.String(), "proceeding without confirmation (--assume-yes)")
+	})
+
+	t.Run("when restart is declined", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, newDeployment(namespacedName, 3))
+		term := NewFakeTerminalWithResponse("n")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, output: "json", errOut: bufferWriter(&bytes.Buffer{})}, "cool-deployment")
+
+		// then
+		require.NoError(t, err)
+		report := readReport(t, term.Output())
+		require.Len(t, report.Actions, 1)
+		assert.Equal(t, "confirmation", report.Actions[0].Action)
+	})
+
+	t.Run("when restart fails", func(t *testing.T) {
+		// given
+		deployment := newDeployment(namespacedName, 3)
+		newClient, fakeClient := NewFakeClients(t, deployment)
+		numberOfUpdateCalls := 0
+		fakeClient.MockUpdate = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
+			numberOfUpdateCalls++
+			if numberOfUpdateCalls > 1 {
+				return fmt.Errorf("some error")
+			}
+			return fakeClient.Client.Update(ctx, obj, opts...)
+		}
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: time.Second, output: "json", errOut: bufferWriter(&bytes.Buffer{})}, "cool-deployment")
+
+		// then
+		require.Error(t, err)
+		report := readReport(t, term.Output())
+		assert.Equal(t, err.Error(), report.Error)
+	})
+
+	t.Run("when deployment is not found", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, newDeployment(namespacedName, 3), newNamespace(namespacedName.Namespace))
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, output: "json", errOut: bufferWriter(&bytes.Buffer{})}, "wrong-deployment")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsNotFound(err), "expected a NotFound error, got: %v", err)
+		report := readReport(t, term.Output())
+		assert.Equal(t, err.Error(), report.Error)
+		require.Len(t, report.Actions, 1)
+		assert.Equal(t, "the deployment was not found", report.Actions[0].Message)
+	})
+
+	t.Run("with unsupported output format", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, newDeployment(namespacedName, 3))
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restart(ctx, restartFlags{targetCluster: "host", timeout: defaultScaleBackTimeout, output: "xml"}, "cool-deployment")
+
+		// then
+		require.ErrorContains(t, err, "unsupported output format")
+	})
 }
 
 func TestRestartDeploymentWithInsufficientPermissions(t *testing.T) {
@@ -137,7 +687,7 @@ func TestRestartDeploymentWithInsufficientPermissions(t *testing.T) {
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := restart(ctx, clusterName, "cool-deployment")
+		err := restart(ctx, restartFlags{targetCluster: clusterName, timeout: defaultScaleBackTimeout}, "cool-deployment")
 
 		// then
 		require.Error(t, err)
@@ -173,6 +723,29 @@ func TestRestartHostOperator(t *testing.T) {
 		require.NoError(t, err)
 		AssertDeploymentHasReplicas(t, fakeClient, namespacedName, 1)
 		assert.Equal(t, 2, numberOfUpdateCalls)
+		assert.NotContains(t, term.Output(), "Found 1 deployment(s)")
+	})
+
+	t.Run("host deployment restart with verbose logs", func(t *testing.T) {
+		// given
+		configuration.Verbose = true
+		t.Cleanup(func() {
+			configuration.Verbose = false
+		})
+		deployment := newDeployment(namespacedName, 1)
+		deployment.Labels = map[string]string{"olm.owner.namespace": "toolchain-host-operator"}
+		newClient, fakeClient := NewFakeClients(t, deployment)
+		term := NewFakeTerminalWithResponse("")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := restartHostOperator(ctx, fakeClient, cfg.OperatorNamespace)
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "Found 1 deployment(s) matching the label olm.owner.namespace=toolchain-host-operator in toolchain-host-operator ns: host-operator-controller-manager")
+		assert.Contains(t, output, "The deployment 'host-operator-controller-manager' in namespace 'toolchain-host-operator' has '1' replicas")
 	})
 
 	t.Run("host deployment with the label is not present - restart fails", func(t *testing.T) {
@@ -225,6 +798,14 @@ func newDeployment(namespacedName types.NamespacedName, replicas int32) *appsv1.
 	}
 }
 
+func newNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
 func requireDeploymentBeingUpdated(t *testing.T, fakeClient *test.FakeClient, namespacedName types.NamespacedName, currentReplicas int32, numberOfUpdateCalls *int) func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
 	return func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.UpdateOption) error {
 		deployment, ok := obj.(*appsv1.Deployment)
@@ -250,3 +831,9 @@ func checkDeploymentBeingUpdated(t *testing.T, fakeClient *test.FakeClient, name
 	}
 	*numberOfUpdateCalls++
 }
+
+func bufferWriter(buf *bytes.Buffer) func() io.Writer {
+	return func() io.Writer {
+		return buf
+	}
+}
@@ -0,0 +1,105 @@
+package adm
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/cmd/flags"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+func NewSetTokenCmd() *cobra.Command {
+	var targetCluster string
+	var tokenFile string
+	command := &cobra.Command{
+		Use:   "set-token -t <cluster-name> [--token-file <path>]",
+		Short: "Updates the token of the given cluster in the ksctl config file",
+		Long: `Updates the token of the given cluster in the ksctl config file, for example when it expired.
+The new token is read from the file given with the '--token-file' flag, or else from the standard input (without echoing it).
+The new token is verified against the API server of the cluster before it is stored.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			token, err := readToken(ctx, tokenFile)
+			if err != nil {
+				return err
+			}
+			return SetToken(ctx, targetCluster, token)
+		},
+	}
+	command.Flags().StringVarP(&targetCluster, "target-cluster", "t", "", "The cluster to update the token of")
+	flags.MustMarkRequired(command, "target-cluster")
+	command.Flags().StringVar(&tokenFile, "token-file", "", "The file containing the new token (default is the standard input)")
+	return command
+}
+
+// SetToken verifies that the given token is valid for the given cluster, then stores it in the ksctl config file
+func SetToken(ctx *clicontext.CommandContext, clusterName, token string) error {
+	if token == "" {
+		return fmt.Errorf("the new token is empty")
+	}
+	// the current token is not needed (it may have expired), only the API server of the cluster
+	clusterDef, err := configuration.LoadClusterAccessDefinition(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	ioutils.RegisterSecrets(token)
+	cl, err := ctx.NewClient(token, clusterDef.ServerAPI)
+	if err != nil {
+		return fmt.Errorf("the new token could not be verified against the API server of the cluster '%s': %w", clusterName, err)
+	}
+	// any authenticated user is allowed to create a SelfSubjectAccessReview, whatever the answer is
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:     "get",
+				Resource: "namespaces",
+			},
+		},
+	}
+	if err := cl.Create(ctx, review); err != nil {
+		return fmt.Errorf("the new token could not be verified against the API server of the cluster '%s': %w", clusterName, err)
+	}
+	path, err := configuration.SetClusterToken(ctx, clusterName, token)
+	if err != nil {
+		return err
+	}
+	ctx.Printlnf("The token of the cluster '%s' was updated in '%s'", clusterName, path)
+	return nil
+}
+
+// readToken reads the new token from the given file or, if no file is given, from the standard input.
+// When the standard input is a terminal, the typed token is not echoed.
+func readToken(ctx *clicontext.CommandContext, tokenFile string) (string, error) {
+	if tokenFile != "" {
+		content, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+	if in, ok := ctx.InOrStdin().(*os.File); ok && term.IsTerminal(int(in.Fd())) {
+		fmt.Fprint(ctx.OutOrStdout(), "Enter the new token: ")
+		content, err := term.ReadPassword(int(in.Fd()))
+		ctx.Println("")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+	content, err := io.ReadAll(ctx.InOrStdin())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,138 @@
+package adm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetToken(t *testing.T) {
+	// given
+	newClientWithToken := func(t *testing.T, fakeClient *test.FakeClient) clicontext.NewClientFunc {
+		return func(_ context.Context, token, apiEndpoint string) (runtimeclient.Client, error) {
+			assert.Equal(t, "new-token", token)
+			assert.Equal(t, "https://cool-server.com", apiEndpoint)
+			return fakeClient, nil
+		}
+	}
+	tokenOf := func(t *testing.T, clusterName string) string {
+		clusterDef, err := configuration.LoadClusterAccessDefinition(NewFakeTerminal(), clusterName)
+		require.NoError(t, err)
+		return clusterDef.Token
+	}
+
+	t.Run("token is updated", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host(), Member())
+		fakeClient := test.NewFakeClient(t)
+		reviewed := false
+		fakeClient.MockCreate = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+			_, reviewed = obj.(*authorizationv1.SelfSubjectAccessReview)
+			return nil
+		}
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClientWithToken(t, fakeClient))
+
+		// when
+		err := SetToken(ctx, "member1", "new-token")
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, reviewed)
+		assert.Equal(t, "new-token", tokenOf(t, "member1"))
+		assert.Equal(t, "cool-token", tokenOf(t, "host"))
+		clusterDef, err := configuration.LoadClusterAccessDefinition(term, "member1")
+		require.NoError(t, err)
+		assert.Equal(t, configuration.Member, clusterDef.ClusterType)
+		assert.Equal(t, "https://cool-server.com", clusterDef.ServerAPI)
+		assert.Contains(t, term.Output(), "The token of the cluster 'member1' was updated in '"+configuration.ConfigFileFlag+"'")
+		assert.NotContains(t, term.Output(), "new-token")
+	})
+
+	t.Run("invalid token is rejected", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host())
+		fakeClient := test.NewFakeClient(t)
+		fakeClient.MockCreate = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+			return apierrors.NewUnauthorized("Unauthorized")
+		}
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClientWithToken(t, fakeClient))
+
+		// when
+		err := SetToken(ctx, "host", "new-token")
+
+		// then
+		require.EqualError(t, err, "the new token could not be verified against the API server of the cluster 'host': Unauthorized")
+		assert.Equal(t, "cool-token", tokenOf(t, "host"))
+	})
+
+	t.Run("empty token is rejected", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host())
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClientWithToken(t, test.NewFakeClient(t)))
+
+		// when
+		err := SetToken(ctx, "host", "")
+
+		// then
+		require.EqualError(t, err, "the new token is empty")
+		assert.Equal(t, "cool-token", tokenOf(t, "host"))
+	})
+
+	t.Run("unknown cluster", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host())
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClientWithToken(t, test.NewFakeClient(t)))
+
+		// when
+		err := SetToken(ctx, "member2", "new-token")
+
+		// then
+		require.ErrorContains(t, err, "the provided cluster-name 'member2' is not present in your ksctl.yaml file")
+	})
+}
+
+func TestReadToken(t *testing.T) {
+	t.Run("from file", func(t *testing.T) {
+		// given
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("new-token\n"), 0600))
+		ctx := clicontext.NewCommandContext(NewFakeTerminal(), nil)
+
+		// when
+		token, err := readToken(ctx, tokenFile)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", token)
+	})
+
+	t.Run("from standard input", func(t *testing.T) {
+		// given
+		term := NewFakeTerminalWithResponse("new-token")
+		ctx := clicontext.NewCommandContext(term, nil)
+
+		// when
+		token, err := readToken(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", token)
+		assert.Empty(t, term.Output())
+	})
+}
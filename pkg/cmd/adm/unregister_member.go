@@ -1,7 +1,6 @@
 package adm
 
 import (
-	"context"
 	"fmt"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
@@ -22,7 +21,7 @@ func NewUnregisterMemberCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 			return UnregisterMemberCluster(ctx, args[0])
 		},
 	}
@@ -33,6 +32,7 @@ func UnregisterMemberCluster(ctx *clicontext.CommandContext, clusterName string)
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(hostClusterConfig, hostClusterConfig.OperatorNamespace)
 	hostClusterClient, err := ctx.NewClient(hostClusterConfig.Token, hostClusterConfig.ServerAPI)
 	if err != nil {
 		return err
@@ -45,19 +45,19 @@ func UnregisterMemberCluster(ctx *clicontext.CommandContext, clusterName string)
 	clusterResourceName := fmt.Sprintf("%s-%s", clusterDef.ClusterType, clusterDef.ServerName)
 
 	toolchainCluster := &toolchainv1alpha1.ToolchainCluster{}
-	if err := hostClusterClient.Get(context.TODO(), types.NamespacedName{Namespace: hostClusterConfig.OperatorNamespace, Name: clusterResourceName}, toolchainCluster); err != nil {
+	if err := hostClusterClient.Get(ctx, types.NamespacedName{Namespace: hostClusterConfig.OperatorNamespace, Name: clusterResourceName}, toolchainCluster); err != nil {
 		return err
 	}
 	if err := ctx.PrintObject(toolchainCluster, "Toolchain Member cluster"); err != nil {
 		return err
 	}
-	confirmation := ctx.AskForConfirmation(ioutils.WithDangerZoneMessagef("unregistering member cluster form host cluster. Make sure there is no users left in the member cluster before unregistering it.",
-		"Delete Member cluster stated above from the Host cluster?"))
+	confirmation := ctx.AskForTypedConfirmation(ioutils.WithDangerZoneMessagef("unregistering member cluster form host cluster. Make sure there is no users left in the member cluster before unregistering it.",
+		"Delete Member cluster stated above from the Host cluster?"), clusterName)
 	if !confirmation {
 		return nil
 	}
 
-	if err := hostClusterClient.Delete(context.TODO(), toolchainCluster); err != nil {
+	if err := hostClusterClient.Delete(ctx, toolchainCluster); err != nil {
 		return err
 	}
 	ctx.Printlnf("\nThe deletion of the Toolchain member cluster from the Host cluster has been triggered")
@@ -22,7 +22,7 @@ func TestUnregisterMemberWhenAnswerIsY(t *testing.T) {
 	fakeClient.MockUpdate = whenDeploymentThenUpdated(t, fakeClient, hostDeploymentName, 1, &numberOfUpdateCalls)
 
 	SetFileConfig(t, Host(), Member())
-	term := NewFakeTerminalWithResponse("y")
+	term := NewFakeTerminalWithResponse("member1")
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
@@ -32,6 +32,7 @@ func TestUnregisterMemberWhenAnswerIsY(t *testing.T) {
 	require.NoError(t, err)
 	AssertToolchainClusterDoesNotExist(t, fakeClient, toolchainCluster)
 	assert.Contains(t, term.Output(), "!!!  DANGER ZONE  !!!")
+	assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 	assert.NotContains(t, term.Output(), "THIS COMMAND WILL CAUSE UNREGISTER MEMBER CLUSTER FORM HOST CLUSTER. MAKE SURE THERE IS NO USERS LEFT IN THE MEMBER CLUSTER BEFORE UNREGISTERING IT")
 	assert.Contains(t, term.Output(), "Delete Member cluster stated above from the Host cluster?")
 	assert.Contains(t, term.Output(), "The deletion of the Toolchain member cluster from the Host cluster has been triggered")
@@ -39,7 +39,7 @@ only one parameter which is the name of the UserSignup to be approved`,
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 			switch {
 			case usersignupName != "":
 				return Approve(ctx, ByName(usersignupName), skipPhone, targetCluster)
@@ -55,12 +55,12 @@ only one parameter which is the name of the UserSignup to be approved`,
 	return command
 }
 
-type LookupUserSignup func(configuration.ClusterConfig, runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error)
+type LookupUserSignup func(context.Context, configuration.ClusterConfig, runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error)
 
 func ByName(name string) LookupUserSignup {
-	return func(cfg configuration.ClusterConfig, cl runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error) {
+	return func(ctx context.Context, cfg configuration.ClusterConfig, cl runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error) {
 		userSignup := &toolchainv1alpha1.UserSignup{}
-		err := cl.Get(context.TODO(), types.NamespacedName{
+		err := cl.Get(ctx, types.NamespacedName{
 			Namespace: cfg.OperatorNamespace,
 			Name:      name,
 		}, userSignup)
@@ -69,9 +69,9 @@ func ByName(name string) LookupUserSignup {
 }
 
 func ByEmailAddress(emailAddress string) LookupUserSignup {
-	return func(cfg configuration.ClusterConfig, cl runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error) {
+	return func(ctx context.Context, cfg configuration.ClusterConfig, cl runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error) {
 		usersignups := toolchainv1alpha1.UserSignupList{}
-		if err := cl.List(context.TODO(), &usersignups, runtimeclient.InNamespace(cfg.OperatorNamespace), runtimeclient.MatchingLabels{
+		if err := cl.List(ctx, &usersignups, runtimeclient.InNamespace(cfg.OperatorNamespace), runtimeclient.MatchingLabels{
 			toolchainv1alpha1.UserSignupUserEmailHashLabelKey: hash.EncodeString(emailAddress),
 		}); err != nil {
 			return nil, err
@@ -91,16 +91,18 @@ func Approve(ctx *clicontext.CommandContext, lookupUserSignup LookupUserSignup,
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
 	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
 	if err != nil {
 		return err
 	}
-	userSignup, err := lookupUserSignup(cfg, cl)
+	userSignup, err := lookupUserSignup(ctx, cfg, cl)
 	if err != nil {
 		return err
 	}
 	if state, found := userSignup.Labels[toolchainv1alpha1.StateLabelKey]; found && state == toolchainv1alpha1.UserSignupStateLabelValueApproved {
-		return fmt.Errorf(`UserSignup "%s" is already approved`, userSignup.Name)
+		ioutils.PrintNothingToDo(ctx, "The UserSignup '%s' is already approved", userSignup.Name)
+		return nil
 	}
 	// check that the usersignup provided a phone number
 	_, found := userSignup.Labels[toolchainv1alpha1.UserSignupUserPhoneHashLabelKey]
@@ -122,7 +124,7 @@ func Approve(ctx *clicontext.CommandContext, lookupUserSignup LookupUserSignup,
 			return err
 		}
 	}
-	if err := cl.Update(context.TODO(), userSignup); err != nil {
+	if err := cl.Update(ctx, userSignup); err != nil {
 		return err
 	}
 	ctx.Printlnf("UserSignup has been approved")
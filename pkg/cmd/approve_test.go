@@ -39,6 +39,7 @@ func TestApprove(t *testing.T) {
 		AssertUserSignupSpec(t, fakeClient, userSignup)
 		output := term.Output()
 		assert.Contains(t, output, "Are you sure that you want to approve the UserSignup above?")
+		assert.Contains(t, output, ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 		assert.Contains(t, output, "UserSignup has been approved")
 		assert.NotContains(t, output, "cool-token")
 	})
@@ -94,7 +95,8 @@ func TestApprove(t *testing.T) {
 		err := cmd.Approve(ctx, dummyGet(userSignup), false, "")
 
 		// then
-		require.EqualError(t, err, fmt.Sprintf(`UserSignup "%s" is already approved`, userSignup.Name))
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "The UserSignup '"+userSignup.Name+"' is already approved: already in desired state; nothing to do")
 		AssertUserSignupSpec(t, fakeClient, userSignup)
 	})
 
@@ -110,7 +112,8 @@ func TestApprove(t *testing.T) {
 		err := cmd.Approve(ctx, dummyGet(userSignup), false, "")
 
 		// then
-		require.EqualError(t, err, fmt.Sprintf(`UserSignup "%s" is already approved`, userSignup.Name))
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "The UserSignup '"+userSignup.Name+"' is already approved: already in desired state; nothing to do")
 		states.SetApprovedManually(userSignup, true) // there's an explicit `spec.state` entry when manually approved
 		AssertUserSignupSpec(t, fakeClient, userSignup)
 	})
@@ -127,7 +130,8 @@ func TestApprove(t *testing.T) {
 		err := cmd.Approve(ctx, dummyGet(userSignup), false, "")
 
 		// then
-		require.EqualError(t, err, fmt.Sprintf(`UserSignup "%s" is already approved`, userSignup.Name))
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "The UserSignup '"+userSignup.Name+"' is already approved: already in desired state; nothing to do")
 		AssertUserSignupSpec(t, fakeClient, userSignup)
 		output := term.Output()
 		assert.NotContains(t, output, "Are you sure that you want to approve the UserSignup above?")
@@ -144,7 +148,7 @@ func TestApprove(t *testing.T) {
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := cmd.Approve(ctx, func(configuration.ClusterConfig, runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error) {
+		err := cmd.Approve(ctx, func(context.Context, configuration.ClusterConfig, runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error) {
 			return nil, fmt.Errorf("mock error")
 		}, false, "")
 
@@ -277,7 +281,7 @@ func TestApprove(t *testing.T) {
 }
 
 func dummyGet(userSignup *toolchainv1alpha1.UserSignup) cmd.LookupUserSignup {
-	return func(configuration.ClusterConfig, runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error) {
+	return func(context.Context, configuration.ClusterConfig, runtimeclient.Client) (*toolchainv1alpha1.UserSignup, error) {
 		return userSignup, nil
 	}
 }
@@ -293,7 +297,7 @@ func TestLookupUserSignupByName(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		result, err := cmd.ByName(userSignup.Name)(cfg, fakeClient)
+		result, err := cmd.ByName(userSignup.Name)(context.TODO(), cfg, fakeClient)
 
 		// then
 		require.NoError(t, err)
@@ -308,7 +312,7 @@ func TestLookupUserSignupByName(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		_, err = cmd.ByName("unknown")(cfg, fakeClient)
+		_, err = cmd.ByName("unknown")(context.TODO(), cfg, fakeClient)
 
 		// then
 		require.Error(t, err)
@@ -327,7 +331,7 @@ func TestLookupUserSignupByName(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		_, err = cmd.ByName(userSignup.Name)(cfg, fakeClient)
+		_, err = cmd.ByName(userSignup.Name)(context.TODO(), cfg, fakeClient)
 
 		// then
 		require.EqualError(t, err, "mock error")
@@ -345,7 +349,7 @@ func TestLookupUserSignupByEmailAddress(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		result, err := cmd.ByEmailAddress(userSignup.Spec.IdentityClaims.Email)(cfg, fakeClient)
+		result, err := cmd.ByEmailAddress(userSignup.Spec.IdentityClaims.Email)(context.TODO(), cfg, fakeClient)
 
 		// then
 		require.NoError(t, err)
@@ -361,7 +365,7 @@ func TestLookupUserSignupByEmailAddress(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		_, err = cmd.ByEmailAddress("unknown@redhat.com")(cfg, fakeClient)
+		_, err = cmd.ByEmailAddress("unknown@redhat.com")(context.TODO(), cfg, fakeClient)
 
 		// then
 		require.EqualError(t, err, "expected a single match with the email address, but found 0")
@@ -377,7 +381,7 @@ func TestLookupUserSignupByEmailAddress(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		_, err = cmd.ByEmailAddress(userSignup1.Spec.IdentityClaims.Email)(cfg, fakeClient)
+		_, err = cmd.ByEmailAddress(userSignup1.Spec.IdentityClaims.Email)(context.TODO(), cfg, fakeClient)
 
 		// then
 		require.EqualError(t, err, "expected a single match with the email address, but found 2")
@@ -395,7 +399,7 @@ func TestLookupUserSignupByEmailAddress(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		_, err = cmd.ByEmailAddress(userSignup.Spec.IdentityClaims.Email)(cfg, fakeClient)
+		_, err = cmd.ByEmailAddress(userSignup.Spec.IdentityClaims.Email)(context.TODO(), cfg, fakeClient)
 
 		// then
 		require.EqualError(t, err, "mock error")
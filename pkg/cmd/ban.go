@@ -1,11 +1,12 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"strings"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/cmd/flags"
 	"github.com/kubesaw/ksctl/pkg/configuration"
 	clicontext "github.com/kubesaw/ksctl/pkg/context"
 	"github.com/kubesaw/ksctl/pkg/ioutils"
@@ -16,24 +17,33 @@ import (
 )
 
 func NewBanCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "ban <usersignup-name>",
+	var reason string
+	command := &cobra.Command{
+		Use:   "ban <usersignup-name> --reason <reason>",
 		Short: "Ban a user for the given UserSignup resource",
 		Long: `Ban the given UserSignup resource. There is expected 
-only one parameter which is the name of the UserSignup to be used for banning`,
+only one parameter which is the name of the UserSignup to be used for banning.
+The reason of the ban is mandatory and is recorded in the BannedUser resource`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
-			return Ban(ctx, args...)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return Ban(ctx, args[0], reason)
 		},
 	}
+	command.Flags().StringVarP(&reason, "reason", "r", "", "The reason of the ban, recorded in the BannedUser resource")
+	flags.MustMarkRequired(command, "reason")
+	return command
 }
 
-const BannedByLabel = toolchainv1alpha1.LabelKeyPrefix + "banned-by"
+const (
+	BannedByLabel = toolchainv1alpha1.LabelKeyPrefix + "banned-by"
+	// BanReasonAnnotation the annotation containing the reason why the user was banned
+	BanReasonAnnotation = toolchainv1alpha1.LabelKeyPrefix + "ban-reason"
+)
 
-func Ban(ctx *clicontext.CommandContext, args ...string) error {
-	return CreateBannedUser(ctx, args[0], func(userSignup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
+func Ban(ctx *clicontext.CommandContext, userSignupName, reason string) error {
+	return CreateBannedUser(ctx, userSignupName, reason, func(userSignup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
 		if _, exists := bannedUser.Labels[toolchainv1alpha1.BannedUserPhoneNumberHashLabelKey]; !exists {
 			ctx.Printlnf("\nINFO: The UserSignup doesn't have the label '%s' set, so the resulting BannedUser resource won't have this label either.\n",
 				toolchainv1alpha1.BannedUserPhoneNumberHashLabelKey)
@@ -50,17 +60,21 @@ func Ban(ctx *clicontext.CommandContext, args ...string) error {
 	})
 }
 
-func CreateBannedUser(ctx *clicontext.CommandContext, userSignupName string, confirm func(*toolchainv1alpha1.UserSignup, *toolchainv1alpha1.BannedUser) (bool, error)) error {
+func CreateBannedUser(ctx *clicontext.CommandContext, userSignupName, reason string, confirm func(*toolchainv1alpha1.UserSignup, *toolchainv1alpha1.BannedUser) (bool, error)) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("the reason of the ban is required")
+	}
 	cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
 	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
 	if err != nil {
 		return err
 	}
 
-	userSignup, err := client.GetUserSignup(cl, cfg.OperatorNamespace, userSignupName)
+	userSignup, err := client.GetUserSignup(ctx, cl, cfg.OperatorNamespace, userSignupName)
 	if err != nil {
 		return err
 	}
@@ -70,7 +84,7 @@ func CreateBannedUser(ctx *clicontext.CommandContext, userSignupName string, con
 		return err
 	}
 
-	bannedUser, err := newBannedUser(userSignup, ksctlConfig.Name)
+	bannedUser, err := newBannedUser(userSignup, ksctlConfig.Name, strings.TrimSpace(reason))
 	if err != nil {
 		return err
 	}
@@ -79,7 +93,7 @@ func CreateBannedUser(ctx *clicontext.CommandContext, userSignupName string, con
 		toolchainv1alpha1.BannedUserEmailHashLabelKey: bannedUser.Labels[toolchainv1alpha1.BannedUserEmailHashLabelKey],
 	})
 	bannedUsers := &toolchainv1alpha1.BannedUserList{}
-	if err := cl.List(context.TODO(), bannedUsers, emailHashLabelMatch, runtimeclient.InNamespace(cfg.OperatorNamespace)); err != nil {
+	if err := cl.List(ctx, bannedUsers, emailHashLabelMatch, runtimeclient.InNamespace(cfg.OperatorNamespace)); err != nil {
 		return err
 	}
 
@@ -87,7 +101,7 @@ func CreateBannedUser(ctx *clicontext.CommandContext, userSignupName string, con
 		return err
 	}
 	if len(bannedUsers.Items) > 0 {
-		ctx.Println("The user was already banned - there is a BannedUser resource with the same labels already present")
+		ioutils.PrintNothingToDo(ctx, "The user was already banned - there is a BannedUser resource with the same labels already present")
 		return ctx.PrintObject(&bannedUsers.Items[0], "BannedUser resource")
 	}
 
@@ -95,7 +109,7 @@ func CreateBannedUser(ctx *clicontext.CommandContext, userSignupName string, con
 		return err
 	}
 
-	if err := cl.Create(context.TODO(), bannedUser); err != nil {
+	if err := cl.Create(ctx, bannedUser); err != nil {
 		return err
 	}
 
@@ -103,7 +117,7 @@ func CreateBannedUser(ctx *clicontext.CommandContext, userSignupName string, con
 	return nil
 }
 
-func newBannedUser(userSignup *toolchainv1alpha1.UserSignup, bannedBy string) (*toolchainv1alpha1.BannedUser, error) {
+func newBannedUser(userSignup *toolchainv1alpha1.UserSignup, bannedBy, reason string) (*toolchainv1alpha1.BannedUser, error) {
 	var emailHashLbl, phoneHashLbl string
 	var exists bool
 
@@ -123,6 +137,9 @@ func newBannedUser(userSignup *toolchainv1alpha1.UserSignup, bannedBy string) (*
 				toolchainv1alpha1.BannedUserEmailHashLabelKey: emailHashLbl,
 				BannedByLabel: bannedBy,
 			},
+			Annotations: map[string]string{
+				BanReasonAnnotation: reason,
+			},
 		},
 		Spec: toolchainv1alpha1.BannedUserSpec{
 			Email: userSignup.Spec.IdentityClaims.Email,
@@ -25,13 +25,14 @@ func TestBanCmdWhenAnswerIsY(t *testing.T) {
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
-	err := cmd.Ban(ctx, userSignup.Name)
+	err := cmd.Ban(ctx, userSignup.Name, "spamming")
 
 	// then
 	require.NoError(t, err)
-	AssertBannedUser(t, fakeClient, userSignup)
+	AssertBannedUser(t, fakeClient, userSignup, "spamming")
 	assert.Contains(t, term.Output(), "!!!  DANGER ZONE  !!!")
 	assert.Contains(t, term.Output(), "Are you sure that you want to ban the user with the UserSignup by creating BannedUser resource that are both above?")
+	assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 	assert.Contains(t, term.Output(), "UserSignup has been banned")
 	assert.NotContains(t, term.Output(), "cool-token")
 
@@ -41,13 +42,13 @@ func TestBanCmdWhenAnswerIsY(t *testing.T) {
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := cmd.Ban(ctx, userSignup.Name)
+		err := cmd.Ban(ctx, userSignup.Name, "spamming")
 
 		// then
 		require.NoError(t, err)
-		AssertBannedUser(t, fakeClient, userSignup)
+		AssertBannedUser(t, fakeClient, userSignup, "spamming")
 		assert.NotContains(t, term.Output(), "!!!  DANGER ZONE  !!!")
-		assert.Contains(t, term.Output(), "The user was already banned - there is a BannedUser resource with the same labels already present")
+		assert.Contains(t, term.Output(), "The user was already banned - there is a BannedUser resource with the same labels already present: already in desired state; nothing to do")
 	})
 }
 
@@ -60,7 +61,7 @@ func TestBanCmdWhenAnswerIsN(t *testing.T) {
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
-	err := cmd.Ban(ctx, userSignup.Name)
+	err := cmd.Ban(ctx, userSignup.Name, "spamming")
 
 	// then
 	require.NoError(t, err)
@@ -80,7 +81,7 @@ func TestBanCmdWhenNotFound(t *testing.T) {
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
-	err := cmd.Ban(ctx, "some")
+	err := cmd.Ban(ctx, "some", "spamming")
 
 	// then
 	require.EqualError(t, err, "usersignups.toolchain.dev.openshift.com \"some\" not found")
@@ -91,6 +92,27 @@ func TestBanCmdWhenNotFound(t *testing.T) {
 	assert.NotContains(t, term.Output(), "cool-token")
 }
 
+func TestBanCmdWhenReasonIsMissing(t *testing.T) {
+	for _, reason := range []string{"", "  "} {
+		t.Run(fmt.Sprintf("reason '%s'", reason), func(t *testing.T) {
+			// given
+			userSignup := NewUserSignup()
+			newClient, fakeClient := NewFakeClients(t, userSignup)
+			SetFileConfig(t, Host())
+			term := NewFakeTerminalWithResponse("y")
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := cmd.Ban(ctx, userSignup.Name, reason)
+
+			// then
+			require.EqualError(t, err, "the reason of the ban is required")
+			AssertNoBannedUser(t, fakeClient, userSignup)
+			assert.NotContains(t, term.Output(), "!!!  DANGER ZONE  !!!")
+		})
+	}
+}
+
 func TestCreateBannedUser(t *testing.T) {
 	// given
 	SetFileConfig(t, Host())
@@ -103,13 +125,13 @@ func TestCreateBannedUser(t *testing.T) {
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := cmd.CreateBannedUser(ctx, userSignup.Name, func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
+		err := cmd.CreateBannedUser(ctx, userSignup.Name, "spamming", func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
 			return true, nil
 		})
 
 		// then
 		require.NoError(t, err)
-		AssertBannedUser(t, fakeClient, userSignup)
+		AssertBannedUser(t, fakeClient, userSignup, "spamming")
 	})
 
 	t.Run("BannedUser should not be created", func(t *testing.T) {
@@ -120,7 +142,7 @@ func TestCreateBannedUser(t *testing.T) {
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := cmd.CreateBannedUser(ctx, userSignup.Name, func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
+		err := cmd.CreateBannedUser(ctx, userSignup.Name, "spamming", func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
 			return false, nil
 		})
 
@@ -137,7 +159,7 @@ func TestCreateBannedUser(t *testing.T) {
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := cmd.CreateBannedUser(ctx, userSignup.Name, func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
+		err := cmd.CreateBannedUser(ctx, userSignup.Name, "spamming", func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
 			return false, fmt.Errorf("some error")
 		})
 
@@ -157,7 +179,7 @@ func TestCreateBannedUser(t *testing.T) {
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := cmd.CreateBannedUser(ctx, userSignup.Name, func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
+		err := cmd.CreateBannedUser(ctx, userSignup.Name, "spamming", func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
 			return true, nil
 		})
 
@@ -177,7 +199,7 @@ func TestCreateBannedUser(t *testing.T) {
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := cmd.CreateBannedUser(ctx, userSignup.Name, func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
+		err := cmd.CreateBannedUser(ctx, userSignup.Name, "spamming", func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
 			return true, nil
 		})
 
@@ -191,13 +213,13 @@ func TestCreateBannedUser(t *testing.T) {
 		userSignup := NewUserSignup()
 		fakeClient := test.NewFakeClient(t, userSignup)
 		term := NewFakeTerminal()
-		newClient := func(token, apiEndpoint string) (runtimeclient.Client, error) {
+		newClient := func(_ context.Context, token, apiEndpoint string) (runtimeclient.Client, error) {
 			return nil, fmt.Errorf("some error")
 		}
 		ctx := clicontext.NewCommandContext(term, newClient)
 
 		// when
-		err := cmd.CreateBannedUser(ctx, userSignup.Name, func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
+		err := cmd.CreateBannedUser(ctx, userSignup.Name, "spamming", func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
 			return true, nil
 		})
 
@@ -217,7 +239,7 @@ func TestCreateBannedUserLacksPermissions(t *testing.T) {
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
-	err := cmd.CreateBannedUser(ctx, userSignup.Name, func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
+	err := cmd.CreateBannedUser(ctx, userSignup.Name, "spamming", func(signup *toolchainv1alpha1.UserSignup, bannedUser *toolchainv1alpha1.BannedUser) (bool, error) {
 		return true, nil
 	})
 
@@ -8,6 +8,7 @@ import (
 	"github.com/kubesaw/ksctl/pkg/client"
 	"github.com/kubesaw/ksctl/pkg/cmd/flags"
 	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
 	"github.com/kubesaw/ksctl/pkg/ioutils"
 
 	"github.com/spf13/cobra"
@@ -17,16 +18,41 @@ import (
 
 type newCmd func(cmdutil.Factory, genericclioptions.IOStreams) *cobra.Command
 
+// kubectlCmdOption an option of the Kubectl command set up by setupKubectlCmd
+type kubectlCmdOption func(*kubectlCmdOptions)
+
+type kubectlCmdOptions struct {
+	destructive bool
+}
+
+// destructive marks the Kubectl command as destructive, so the user is asked for a confirmation before it runs
+func destructive() kubectlCmdOption {
+	return func(options *kubectlCmdOptions) {
+		options.destructive = true
+	}
+}
+
 // setupKubectlCmd takes care of setting up the flags and PreRunE func on the given Kubectl command
-func setupKubectlCmd(newCmd newCmd) *cobra.Command {
+func setupKubectlCmd(newCmd newCmd, opts ...kubectlCmdOption) *cobra.Command {
+	options := &kubectlCmdOptions{}
+	for _, apply := range opts {
+		apply(options)
+	}
 	kubeConfigFlags := genericclioptions.NewConfigFlags(true).WithDeprecatedPasswordFlag()
 	factory := cmdutil.NewFactory(cmdutil.NewMatchVersionFlags(kubeConfigFlags))
+	// the streams are resolved when the command runs, so the output can be redirected (eg, in tests),
+	// and the secrets (eg, the token of the cluster) are redacted from them
+	var cmd *cobra.Command
 	ioStreams := genericclioptions.IOStreams{
-		In:     os.Stdin,
-		Out:    os.Stdout,
-		ErrOut: os.Stderr,
+		In: os.Stdin,
+		Out: ioutils.RedactingWriter(writerFunc(func(p []byte) (int, error) {
+			return cmd.OutOrStdout().Write(p)
+		})),
+		ErrOut: ioutils.RedactingWriter(writerFunc(func(p []byte) (int, error) {
+			return cmd.ErrOrStderr().Write(p)
+		})),
 	}
-	cmd := newCmd(factory, ioStreams)
+	cmd = newCmd(factory, ioStreams)
 	cmd.Example = strings.ReplaceAll(cmd.Example, "kubectl ", "ksctl ")
 
 	// hide unused/redefined flags
@@ -38,11 +64,14 @@ func setupKubectlCmd(newCmd newCmd) *cobra.Command {
 	cmd.Flags().StringP("target-cluster", "t", "", "Target cluster")
 	// will be used to load the config (API Server URL and token)
 	flags.MustMarkRequired(cmd, "target-cluster")
+	flags.MustRegisterCompletionFunc(cmd, "target-cluster", completeClusterFlag)
 	// flags with values hard-coded by `PreRun` are hidden
 	flags.MustMarkHidden(cmd, "server")
 	flags.MustMarkHidden(cmd, "token")
 	flags.MustMarkHidden(cmd, "kubeconfig")
 
+	// the command does not run if the user declined the confirmation (for destructive commands only)
+	confirmed := true
 	// set the "hard-coded" value of some specific flags before running the command,
 	// by loading the config associated with the `--cluster` flag
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
@@ -50,6 +79,10 @@ func setupKubectlCmd(newCmd newCmd) *cobra.Command {
 		if clusterName == "" { // flag is required, but we need to manually verify its presence in the PreRun
 			return fmt.Errorf("you must specify the target cluster")
 		}
+		if kubeconfig := cmd.Flag("kubeconfig"); kubeconfig.Changed {
+			// the `kubeconfig` flag of the kubectl command shadows the global flag
+			configuration.KubeconfigFlag = kubeconfig.Value.String()
+		}
 		term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
 		cfg, err := configuration.LoadClusterConfig(term, clusterName)
 		if err != nil {
@@ -58,6 +91,9 @@ func setupKubectlCmd(newCmd newCmd) *cobra.Command {
 		if !cmd.Flag("namespace").Changed { // default to kubeSaw namespace
 			kubeConfigFlags.Namespace = &cfg.OperatorNamespace
 		}
+		if !cmd.Flag("as").Changed && configuration.Impersonate != "" {
+			kubeConfigFlags.Impersonate = &configuration.Impersonate
+		}
 		kubeConfigFlags.APIServer = &cfg.ServerAPI
 		kubeConfigFlags.BearerToken = &cfg.Token
 		kubeconfig, err := client.EnsureKsctlConfigFile()
@@ -65,7 +101,28 @@ func setupKubectlCmd(newCmd newCmd) *cobra.Command {
 			return err
 		}
 		kubeConfigFlags.KubeConfig = &kubeconfig
+		if options.destructive {
+			ctx := clicontext.NewCommandContext(term, nil)
+			ctx.SetTarget(cfg, *kubeConfigFlags.Namespace)
+			confirmed = ctx.AskForConfirmation(ioutils.WithDangerZoneMessagef(
+				"changes to the resources of the cluster",
+				"run '%s' on the '%s' cluster?", strings.TrimSpace(cmd.CommandPath()+" "+strings.Join(args, " ")), clusterName))
+		}
 		return nil
 	}
+	if run := cmd.Run; options.destructive && run != nil {
+		cmd.Run = func(cmd *cobra.Command, args []string) {
+			if confirmed {
+				run(cmd, args)
+			}
+		}
+	}
 	return cmd
 }
+
+// writerFunc an adapter to use a func as an io.Writer
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+func TestSetupDestructiveKubectlCmd(t *testing.T) {
+	// given
+	test.SetFileConfig(t, test.Host())
+	newDeleteCmd := func(ran *bool, answer string, opts ...kubectlCmdOption) (*cobra.Command, *bytes.Buffer) {
+		command := setupKubectlCmd(func(_ cmdutil.Factory, _ genericclioptions.IOStreams) *cobra.Command {
+			return &cobra.Command{
+				Use: "delete",
+				Run: func(_ *cobra.Command, _ []string) {
+					*ran = true
+				},
+			}
+		}, opts...)
+		out := bytes.NewBuffer(nil)
+		command.SetIn(strings.NewReader(answer + "\n"))
+		command.SetOut(out)
+		command.SetArgs([]string{"-t", "host", "pods", "cool-pod"})
+		return command, out
+	}
+
+	t.Run("runs when confirmed", func(t *testing.T) {
+		// given
+		ran := false
+		command, out := newDeleteCmd(&ran, "y", destructive())
+
+		// when
+		err := command.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, ran)
+		assert.Contains(t, out.String(), "!!!  DANGER ZONE  !!!")
+		assert.Contains(t, out.String(), "Are you sure that you want to run 'delete pods cool-pod' on the 'host' cluster?")
+		assert.Contains(t, out.String(), ">>> target cluster: 'host'")
+	})
+
+	t.Run("does not run when declined", func(t *testing.T) {
+		// given
+		ran := false
+		command, out := newDeleteCmd(&ran, "n", destructive())
+
+		// when
+		err := command.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, ran)
+		assert.Contains(t, out.String(), "Are you sure that you want to run 'delete pods cool-pod' on the 'host' cluster?")
+	})
+
+	t.Run("does not ask for confirmation when not destructive", func(t *testing.T) {
+		// given
+		ran := false
+		command, out := newDeleteCmd(&ran, "n")
+
+		// when
+		err := command.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, ran)
+		assert.NotContains(t, out.String(), "Are you sure")
+	})
+}
+
+func TestKubectlCmdRedactsSecrets(t *testing.T) {
+	// given
+	server := newTokenEchoingServer(t)
+	defer server.Close()
+	test.SetFileConfig(t, test.Host(test.ServerAPI(server.URL)))
+	newGetCmd := func(args ...string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+		command := NewGetCmd()
+		out := bytes.NewBuffer(nil)
+		errOut := bytes.NewBuffer(nil)
+		command.SetOut(out)
+		command.SetErr(errOut)
+		command.SetArgs(append([]string{"-t=host", "--insecure-skip-tls-verify=true"}, args...))
+		return command, out, errOut
+	}
+
+	t.Run("in the output", func(t *testing.T) {
+		// given
+		command, out, _ := newGetCmd("pods", "-o=yaml")
+
+		// when
+		err := command.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "echoed-token: *****")
+		assert.NotContains(t, out.String(), "cool-token")
+	})
+
+	t.Run("in the error output", func(t *testing.T) {
+		// given
+		// the namespace is the token, so it is part of the "No resources found" message printed in the error output
+		command, out, errOut := newGetCmd("pods", "-n=cool-token")
+
+		// when
+		err := command.Execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, out.String())
+		assert.Contains(t, errOut.String(), "No resources found in ***** namespace.")
+		assert.NotContains(t, errOut.String(), "cool-token")
+	})
+}
+
+// newTokenEchoingServer returns a new HTTPS Server (the token is not sent over HTTP) which returns the pods with the bearer token
+// of the request in an annotation, or no pod at all in any other namespace than `toolchain-host-operator`
+func newTokenEchoingServer(t *testing.T) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var response interface{}
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/api":
+			response = &metav1.APIVersions{
+				Versions: []string{"v1"},
+			}
+		case req.Method == "GET" && req.URL.Path == "/apis":
+			response = &metav1.APIGroupList{
+				Groups: []metav1.APIGroup{},
+			}
+		case req.Method == "GET" && req.URL.Path == "/api/v1":
+			response = &metav1.APIResourceList{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name:         "pods",
+						SingularName: "pod",
+						Namespaced:   true,
+						Kind:         "Pod",
+						Verbs:        []string{"get", "list"},
+					},
+				},
+			}
+		case req.Method == "GET" && req.URL.Path == "/api/v1/namespaces/toolchain-host-operator/pods":
+			response = &corev1.PodList{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "PodList",
+				},
+				Items: []corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "toolchain-host-operator",
+							Name:      "cheesecake",
+							Annotations: map[string]string{
+								"echoed-token": strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "),
+							},
+						},
+					},
+				},
+			}
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/pods"):
+			response = &corev1.PodList{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "PodList",
+				},
+			}
+		default:
+			t.Errorf("unexpected request: %s %s\n", req.Method, req.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		output, err := json.Marshal(response)
+		if err != nil {
+			t.Errorf("unexpected encoding error: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(output) // nolint: errcheck
+	}))
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewClustersCmd() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "clusters",
+		Short: "List the configured clusters",
+		Long: `List the clusters configured in the config file, ie, the valid values of the '--target-cluster' flag,
+along with their type, server API and operator namespace`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return Clusters(ctx, output)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json")
+	return command
+}
+
+// ClusterSummary a cluster configured in the config file (the token is deliberately omitted)
+type ClusterSummary struct {
+	Name              string `json:"name"`
+	ClusterType       string `json:"clusterType"`
+	ServerAPI         string `json:"serverAPI"`
+	OperatorNamespace string `json:"operatorNamespace"`
+}
+
+func Clusters(ctx *clicontext.CommandContext, output string) error {
+	if err := ioutils.ValidateOutputFormat(output); err != nil {
+		return err
+	}
+	clusterConfigs, err := configuration.LoadClusterConfigs(ctx)
+	if err != nil {
+		return err
+	}
+	clusters := make([]ClusterSummary, 0, len(clusterConfigs))
+	for _, cfg := range clusterConfigs {
+		clusters = append(clusters, ClusterSummary{
+			Name:              cfg.ClusterName,
+			ClusterType:       cfg.ClusterType.String(),
+			ServerAPI:         cfg.ServerAPI,
+			OperatorNamespace: cfg.OperatorNamespace,
+		})
+	}
+
+	if output == "json" {
+		return ioutils.PrintJSON(ctx, clusters)
+	}
+	w := tabwriter.NewWriter(ctx.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tSERVER API\tOPERATOR NAMESPACE")
+	for _, c := range clusters {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Name, c.ClusterType, c.ServerAPI, c.OperatorNamespace)
+	}
+	return w.Flush()
+}
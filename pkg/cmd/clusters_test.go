@@ -0,0 +1,83 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusters(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member(ServerAPI("https://api.member.com:6443")))
+	newClient, _ := NewFakeClients(t)
+
+	t.Run("as table", func(t *testing.T) {
+		// given
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.Clusters(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "NAME       TYPE     SERVER API                    OPERATOR NAMESPACE")
+		assert.Contains(t, output, "host       host     https://cool-server.com       toolchain-host-operator")
+		assert.Contains(t, output, "member-1   member   https://api.member.com:6443   toolchain-member-operator")
+		assert.NotContains(t, output, "cool-token")
+	})
+
+	t.Run("as json", func(t *testing.T) {
+		// given
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.Clusters(ctx, "json")
+
+		// then
+		require.NoError(t, err)
+		assert.NotContains(t, term.Output(), "token")
+		clusters := []cmd.ClusterSummary{}
+		require.NoError(t, json.Unmarshal([]byte(term.Output()), &clusters))
+		assert.Equal(t, []cmd.ClusterSummary{
+			{Name: "host", ClusterType: "host", ServerAPI: "https://cool-server.com", OperatorNamespace: "toolchain-host-operator"},
+			{Name: "member-1", ClusterType: "member", ServerAPI: "https://api.member.com:6443", OperatorNamespace: "toolchain-member-operator"},
+		}, clusters)
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		// given
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.Clusters(ctx, "yaml")
+
+		// then
+		require.EqualError(t, err, "unsupported output format 'yaml', the only supported format is 'json'")
+	})
+
+	t.Run("with operator namespace set in the config file", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host(OperatorNamespace("sandbox-host")), Member())
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.Clusters(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "host       host     https://cool-server.com   sandbox-host")
+		assert.Contains(t, output, "member-1   member   https://cool-server.com   toolchain-member-operator")
+	})
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout the maximum time spent connecting to the cluster and listing the resources to complete,
+// so the shell is not blocked when the cluster is not reachable
+const completionTimeout = 3 * time.Second
+
+// completeSpaceName completes the first argument of a command with the names of the Spaces
+func completeSpaceName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeSpaceFlag(cmd, args, toComplete)
+}
+
+// completeSpaceFlag completes the value of a flag with the names of the Spaces
+func completeSpaceFlag(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// nothing but the completions should be written in the output
+	term := ioutils.NewTerminal(cmd.InOrStdin, func() io.Writer {
+		return io.Discard
+	})
+	// the whole completion is bounded, including the client creation (which is not retried)
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	ctx := clicontext.NewCommandContext(term, client.NewClientWithContext).WithContext(timeoutCtx)
+	names, err := SpaceNames(ctx, toComplete)
+	if err != nil {
+		// completion should never fail, so there is just nothing to suggest
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// SpaceNames returns the sorted names of the Spaces starting with the given prefix
+func SpaceNames(ctx *clicontext.CommandContext, prefix string) ([]string, error) {
+	cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	err = client.ListSpaces(ctx, cl, cfg.OperatorNamespace, func(spaces []toolchainv1alpha1.Space) error {
+		for _, space := range spaces {
+			if strings.HasPrefix(space.Name, prefix) {
+				names = append(names, space.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// completeClusterFlag completes the value of a flag with the names of the clusters configured in the config file
+func completeClusterFlag(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// nothing but the completions should be written in the output
+	term := ioutils.NewTerminal(cmd.InOrStdin, func() io.Writer {
+		return io.Discard
+	})
+	names, err := ClusterNames(term, toComplete)
+	if err != nil {
+		// completion should never fail, so there is just nothing to suggest
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// ClusterNames returns the sorted names of the configured clusters starting with the given prefix
+func ClusterNames(term ioutils.Terminal, prefix string) ([]string, error) {
+	allNames, err := configuration.AllClusterNames(term)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range allNames {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
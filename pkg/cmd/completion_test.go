@@ -0,0 +1,118 @@
+package cmd_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSpaceNames(t *testing.T) {
+	// given
+	SetFileConfig(t, Host())
+	spaces := []runtime.Object{
+		newNamedSpace("john"),
+		newNamedSpace("jane"),
+		newNamedSpace("bob"),
+	}
+
+	t.Run("all spaces", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, spaces...)
+		ctx := clicontext.NewCommandContext(NewFakeTerminal(), newClient)
+
+		// when
+		names, err := cmd.SpaceNames(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob", "jane", "john"}, names)
+	})
+
+	t.Run("spaces with prefix", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, spaces...)
+		ctx := clicontext.NewCommandContext(NewFakeTerminal(), newClient)
+
+		// when
+		names, err := cmd.SpaceNames(ctx, "j")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"jane", "john"}, names)
+	})
+
+	t.Run("when listing fails", func(t *testing.T) {
+		// given
+		newClient, fakeClient := NewFakeClients(t, spaces...)
+		fakeClient.MockList = func(ctx context.Context, list runtimeclient.ObjectList, opts ...runtimeclient.ListOption) error {
+			return fmt.Errorf("mock error")
+		}
+		ctx := clicontext.NewCommandContext(NewFakeTerminal(), newClient)
+
+		// when
+		names, err := cmd.SpaceNames(ctx, "")
+
+		// then
+		require.EqualError(t, err, "mock error")
+		assert.Empty(t, names)
+	})
+}
+
+func TestClusterNames(t *testing.T) {
+	t.Run("all clusters", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Member(ClusterName("member-2")), Host(), Member())
+
+		// when
+		names, err := cmd.ClusterNames(NewFakeTerminal(), "")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"host", "member-1", "member-2"}, names)
+	})
+
+	t.Run("clusters with prefix", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Member(ClusterName("member-2")), Host(), Member())
+
+		// when
+		names, err := cmd.ClusterNames(NewFakeTerminal(), "mem")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"member-1", "member-2"}, names)
+	})
+
+	t.Run("when no cluster is configured", func(t *testing.T) {
+		// given
+		SetFileConfig(t)
+
+		// when
+		names, err := cmd.ClusterNames(NewFakeTerminal(), "")
+
+		// then
+		require.Error(t, err)
+		assert.Empty(t, names)
+	})
+}
+
+func newNamedSpace(name string) *toolchainv1alpha1.Space {
+	return &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: test.HostOperatorNs,
+		},
+	}
+}
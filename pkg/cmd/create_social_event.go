@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -36,7 +35,7 @@ func NewCreateSocialEventCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 			return CreateSocialEvent(ctx, startDate, endDate, description, userTier, spaceTier, maxAttendees, preferSameCluster)
 		},
 	}
@@ -78,7 +77,7 @@ func CreateSocialEvent(ctx *clicontext.CommandContext, startDate, endDate, descr
 		return errs.New("end date is not after start date")
 	}
 	// check that the user and space tiers exist
-	if err := cl.Get(context.TODO(), types.NamespacedName{
+	if err := cl.Get(ctx, types.NamespacedName{
 		Namespace: cfg.OperatorNamespace,
 		Name:      userTier,
 	}, &toolchainv1alpha1.UserTier{}); err != nil {
@@ -86,7 +85,7 @@ func CreateSocialEvent(ctx *clicontext.CommandContext, startDate, endDate, descr
 			return fmt.Errorf("UserTier '%s' does not exist", userTier)
 		}
 	}
-	if err := cl.Get(context.TODO(), types.NamespacedName{
+	if err := cl.Get(ctx, types.NamespacedName{
 		Namespace: cfg.OperatorNamespace,
 		Name:      spaceTier,
 	}, &toolchainv1alpha1.NSTemplateTier{}); err != nil {
@@ -111,7 +110,7 @@ func CreateSocialEvent(ctx *clicontext.CommandContext, startDate, endDate, descr
 		},
 	}
 
-	if err := cl.Create(context.TODO(), se); err != nil {
+	if err := cl.Create(ctx, se); err != nil {
 		return err
 	}
 	ctx.Printlnf("Social Event successfully created. Activation code is '%s'", se.Name)
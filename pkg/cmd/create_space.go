@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/cmd/flags"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func NewCreateSpaceCmd() *cobra.Command {
+	var tier string
+	var targetCluster string
+	command := &cobra.Command{
+		Use:   "create-space <space-name> --tier <tier> --target-cluster <member-cluster>",
+		Short: "Create a Space with the given name",
+		Long: `Create a Space with the given name in the host operator namespace, using the given NSTemplateTier
+and provisioned on the given member cluster. There is expected only one parameter which is the name of the Space`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return CreateSpace(ctx, args[0], tier, targetCluster)
+		},
+	}
+	command.Flags().StringVar(&tier, "tier", "", "The name of the NSTemplateTier of the Space")
+	flags.MustMarkRequired(command, "tier")
+	command.Flags().StringVarP(&targetCluster, "target-cluster", "t", "", "The name of the member cluster where the Space is provisioned")
+	flags.MustMarkRequired(command, "target-cluster")
+	return command
+}
+
+func CreateSpace(ctx *clicontext.CommandContext, spaceName, tier, targetCluster string) error {
+	cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
+	if err != nil {
+		return err
+	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
+	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
+	if err != nil {
+		return err
+	}
+
+	memberClusterConfig, err := configuration.LoadClusterConfig(ctx, targetCluster)
+	if err != nil {
+		return err
+	}
+	// target cluster must have 'member' cluster type
+	if memberClusterConfig.ClusterType != configuration.Member {
+		return fmt.Errorf("expected target cluster to have clusterType '%s', actual: '%s'", configuration.Member, memberClusterConfig.ClusterType)
+	}
+
+	// verify the NSTemplateTier exists
+	if _, err := client.GetNSTemplateTier(ctx, cfg, cl, tier); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("NSTemplateTier '%s' does not exist", tier)
+		}
+		return err
+	}
+
+	if _, err := client.GetSpace(ctx, cl, cfg.OperatorNamespace, spaceName); err == nil {
+		return fmt.Errorf("the Space '%s' already exists", spaceName)
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spaceName,
+			Namespace: cfg.OperatorNamespace,
+		},
+		Spec: toolchainv1alpha1.SpaceSpec{
+			TargetCluster: memberToolchainClusterName(memberClusterConfig),
+			TierName:      tier,
+		},
+	}
+	if err := ctx.PrintObject(space, "Space to be created"); err != nil {
+		return err
+	}
+	if !ctx.AskForConfirmation(ioutils.WithMessagef("create the Space '%s' in the '%s' tier on cluster '%s'?", spaceName, tier, targetCluster)) {
+		return nil
+	}
+
+	if err := cl.Create(ctx, space); err != nil {
+		return err
+	}
+	ctx.Printlnf("\nSpace '%s' has been created", spaceName)
+	return nil
+}
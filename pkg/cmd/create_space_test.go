@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-common/pkg/test"
+	testspace "github.com/codeready-toolchain/toolchain-common/pkg/test/space"
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSpace(t *testing.T) {
+	// given
+	SetFileConfig(t,
+		Host(),
+		Member(ClusterName("member1"), ServerName("m1.devcluster.openshift.com")))
+
+	t.Run("when answer is Y", func(t *testing.T) {
+		// given
+		newClient, fakeClient := NewFakeClients(t, newNSTemplateTier("base"))
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.CreateSpace(ctx, "john", "base", "member1")
+
+		// then
+		require.NoError(t, err)
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).
+			HasTier("base").
+			HasSpecTargetCluster("member-m1.devcluster.openshift.com")
+		assert.Contains(t, term.Output(), "Are you sure that you want to create the Space 'john' in the 'base' tier on cluster 'member1'?")
+		assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
+		assert.Contains(t, term.Output(), "Space 'john' has been created")
+		assert.NotContains(t, term.Output(), "cool-token")
+	})
+
+	t.Run("when answer is N", func(t *testing.T) {
+		// given
+		newClient, fakeClient := NewFakeClients(t, newNSTemplateTier("base"))
+		term := NewFakeTerminalWithResponse("n")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.CreateSpace(ctx, "john", "base", "member1")
+
+		// then
+		require.NoError(t, err)
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).DoesNotExist()
+		assert.NotContains(t, term.Output(), "has been created")
+	})
+
+	t.Run("when Space already exists", func(t *testing.T) {
+		// given
+		space := testspace.NewSpace(test.HostOperatorNs, "john", testspace.WithTierName("advanced"))
+		newClient, fakeClient := NewFakeClients(t, space, newNSTemplateTier("base"))
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.CreateSpace(ctx, "john", "base", "member1")
+
+		// then
+		require.EqualError(t, err, "the Space 'john' already exists")
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).HasTier("advanced")
+		assert.NotContains(t, term.Output(), "Are you sure")
+	})
+
+	t.Run("when tier does not exist", func(t *testing.T) {
+		// given
+		newClient, fakeClient := NewFakeClients(t)
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.CreateSpace(ctx, "john", "base", "member1")
+
+		// then
+		require.EqualError(t, err, "NSTemplateTier 'base' does not exist")
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).DoesNotExist()
+	})
+
+	t.Run("when target cluster is not a member", func(t *testing.T) {
+		// given
+		newClient, fakeClient := NewFakeClients(t, newNSTemplateTier("base"))
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.CreateSpace(ctx, "john", "base", "host")
+
+		// then
+		require.EqualError(t, err, "expected target cluster to have clusterType 'member', actual: 'host'")
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).DoesNotExist()
+	})
+
+	t.Run("when target cluster is unknown", func(t *testing.T) {
+		// given
+		newClient, fakeClient := NewFakeClients(t, newNSTemplateTier("base"))
+		term := NewFakeTerminalWithResponse("Y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.CreateSpace(ctx, "john", "base", "member2")
+
+		// then
+		require.ErrorContains(t, err, "the provided cluster-name 'member2' is not present in your ksctl.yaml file")
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).DoesNotExist()
+	})
+}
@@ -19,7 +19,7 @@ only one parameter which is the name of the UserSignup to be deactivated`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 			return Deactivate(ctx, args...)
 		},
 	}
@@ -27,6 +27,10 @@ only one parameter which is the name of the UserSignup to be deactivated`,
 
 func Deactivate(ctx *clicontext.CommandContext, args ...string) error {
 	return client.PatchUserSignup(ctx, args[0], func(userSignup *toolchainv1alpha1.UserSignup) (bool, error) {
+		if states.Deactivated(userSignup) {
+			ioutils.PrintNothingToDo(ctx, "The UserSignup '%s' is already deactivated", userSignup.Name)
+			return false, nil
+		}
 		if err := ctx.PrintObject(userSignup, "UserSignup to be deactivated"); err != nil {
 			return false, err
 		}
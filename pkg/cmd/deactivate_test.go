@@ -1,6 +1,7 @@
 package cmd_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/codeready-toolchain/toolchain-common/pkg/states"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestDeactivateCmdWhenAnswerIsY(t *testing.T) {
@@ -30,6 +32,7 @@ func TestDeactivateCmdWhenAnswerIsY(t *testing.T) {
 	AssertUserSignupSpec(t, fakeClient, userSignup)
 	assert.Contains(t, term.Output(), "!!!  DANGER ZONE  !!!")
 	assert.Contains(t, term.Output(), "Are you sure that you want to deactivate the UserSignup above?")
+	assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 	assert.Contains(t, term.Output(), "UserSignup has been deactivated")
 	assert.NotContains(t, term.Output(), "cool-token")
 }
@@ -54,6 +57,26 @@ func TestDeactivateCmdWhenAnswerIsN(t *testing.T) {
 	assert.NotContains(t, term.Output(), "cool-token")
 }
 
+func TestDeactivateCmdWhenAlreadyDeactivated(t *testing.T) {
+	// given
+	userSignup := NewUserSignup(UserSignupDeactivated(true))
+	newClient, fakeClient := NewFakeClients(t, userSignup)
+	SetFileConfig(t, Host())
+	term := NewFakeTerminalWithResponse("y")
+	ctx := clicontext.NewCommandContext(term, newClient)
+
+	// when
+	err := cmd.Deactivate(ctx, userSignup.Name)
+
+	// then
+	require.NoError(t, err)
+	AssertUserSignupSpec(t, fakeClient, userSignup)
+	assert.Contains(t, term.Output(), "The UserSignup '"+userSignup.Name+"' is already deactivated: already in desired state; nothing to do")
+	assert.NotContains(t, term.Output(), "Are you sure that you want to deactivate the UserSignup above?")
+	assert.NotContains(t, term.Output(), "UserSignup has been deactivated")
+	assert.NotContains(t, term.Output(), "cool-token")
+}
+
 func TestDeactivateCmdWhenNotFound(t *testing.T) {
 	// given
 	userSignup := NewUserSignup()
@@ -73,3 +96,28 @@ func TestDeactivateCmdWhenNotFound(t *testing.T) {
 	assert.NotContains(t, term.Output(), "UserSignup has been deactivated")
 	assert.NotContains(t, term.Output(), "cool-token")
 }
+
+func TestDeactivateCmdWhenContextIsCancelled(t *testing.T) {
+	// given
+	userSignup := NewUserSignup()
+	newClient, fakeClient := NewFakeClients(t, userSignup)
+	fakeClient.MockGet = func(ctx context.Context, key runtimeclient.ObjectKey, obj runtimeclient.Object, opts ...runtimeclient.GetOption) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fakeClient.Client.Get(ctx, key, obj, opts...)
+	}
+	SetFileConfig(t, Host())
+	term := NewFakeTerminalWithResponse("y")
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx := clicontext.NewCommandContext(term, newClient).WithContext(cancelledCtx)
+
+	// when
+	err := cmd.Deactivate(ctx, userSignup.Name)
+
+	// then
+	require.ErrorIs(t, err, context.Canceled)
+	AssertUserSignupSpec(t, fakeClient, userSignup)
+	assert.NotContains(t, term.Output(), "UserSignup has been deactivated")
+}
@@ -1,62 +1,104 @@
 package cmd
 
 import (
-	"context"
+	"fmt"
 
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	"github.com/kubesaw/ksctl/pkg/client"
 	"github.com/kubesaw/ksctl/pkg/configuration"
 	clicontext "github.com/kubesaw/ksctl/pkg/context"
 	"github.com/kubesaw/ksctl/pkg/ioutils"
 
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func NewGdprDeleteCmd() *cobra.Command {
-	return &cobra.Command{
+	var dryRun bool
+	var propagationPolicy string
+	command := &cobra.Command{
 		Use:   "gdpr-delete <usersignup-name>",
 		Short: "Delete the given UserSignup resource",
 		Long: `Delete the given UserSignup resource. There is expected 
-only one parameter which is the name of the UserSignup to be deleted`,
+only one parameter which is the name of the UserSignup to be deleted.
+The name of the UserSignup needs to be typed again to confirm the deletion.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
-			return Delete(ctx, args...)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return Delete(ctx, args[0], dryRun, propagationPolicy)
 		},
 	}
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the resources that would be deleted, without deleting anything")
+	addPropagationPolicyFlag(command, &propagationPolicy, "foreground")
+	return command
 }
 
-func Delete(ctx *clicontext.CommandContext, args ...string) error {
+func Delete(ctx *clicontext.CommandContext, userSignupName string, dryRun bool, propagationPolicy string) error {
+	propagation, err := deletionPropagation(propagationPolicy)
+	if err != nil {
+		return err
+	}
 	cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
 	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
 	if err != nil {
 		return err
 	}
-	userSignup, err := client.GetUserSignup(cl, cfg.OperatorNamespace, args[0])
+	userSignup, err := client.GetUserSignup(ctx, cl, cfg.OperatorNamespace, userSignupName)
 	if err != nil {
 		return err
 	}
+	if dryRun {
+		return printResourcesToDelete(ctx, cl, cfg.OperatorNamespace, userSignup, "DRY RUN: the following resources would be deleted")
+	}
 	if err := ctx.PrintObject(userSignup, "UserSignup to be deleted"); err != nil {
 		return err
 	}
-	confirmation := ctx.AskForConfirmation(ioutils.WithDangerZoneMessagef(
+	if err := printResourcesToDelete(ctx, cl, cfg.OperatorNamespace, userSignup, "The following resources will be deleted"); err != nil {
+		return err
+	}
+	confirmation := ctx.AskForTypedConfirmation(ioutils.WithDangerZoneMessagef(
 		"deletion of all user's namespaces and all related data.\n"+
-			"This command should be executed based on GDPR request.", "delete the UserSignup above?"))
+			"This command should be executed based on GDPR request.", "delete the UserSignup above?"), userSignup.Name)
 	if !confirmation {
 		return nil
 	}
-	propagationPolicy := metav1.DeletePropagationForeground
-	opts := runtimeclient.DeleteOption(&runtimeclient.DeleteOptions{
-		PropagationPolicy: &propagationPolicy,
-	})
-	if err := cl.Delete(context.TODO(), userSignup, opts); err != nil {
+	if err := cl.Delete(ctx, userSignup, runtimeclient.PropagationPolicy(propagation)); err != nil {
 		return err
 	}
 	ctx.Printlnf("\nThe deletion of the UserSignup has been triggered")
 	return nil
 }
+
+// printResourcesToDelete prints the UserSignup and the resources which are deleted along with it
+func printResourcesToDelete(ctx *clicontext.CommandContext, cl runtimeclient.Client, namespace string, userSignup *toolchainv1alpha1.UserSignup, title string) error {
+	resources := fmt.Sprintf("\n- UserSignup '%s'\n", userSignup.Name)
+	if userSignup.Status.CompliantUsername != "" {
+		if _, err := client.GetMasterUserRecord(ctx, cl, namespace, userSignup.Status.CompliantUsername); err == nil {
+			resources += fmt.Sprintf("- MasterUserRecord '%s'\n", userSignup.Status.CompliantUsername)
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	spaceName := userSignup.Status.HomeSpace
+	if spaceName == "" {
+		spaceName = userSignup.Status.CompliantUsername
+	}
+	if spaceName != "" {
+		if space, err := client.GetSpace(ctx, cl, namespace, spaceName); err == nil {
+			resources += fmt.Sprintf("- Space '%s'\n", space.Name)
+			for _, ns := range space.Status.ProvisionedNamespaces {
+				resources += fmt.Sprintf("  - Namespace '%s' in cluster '%s'\n", ns.Name, space.Status.TargetCluster)
+			}
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	ctx.PrintContextSeparatorWithBodyf(resources, title)
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewDeleteSpaceCmd() *cobra.Command {
+	var dryRun bool
+	var propagationPolicy string
+	command := &cobra.Command{
+		Use:   "delete-space <space-name>",
+		Short: "Delete the Space with the given name",
+		Long: `Delete the Space with the given name from the host operator namespace, so all its namespaces are reclaimed.
+There is expected only one parameter which is the name of the Space`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSpaceName,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return DeleteSpace(ctx, args[0], dryRun, propagationPolicy)
+		},
+	}
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Only print the Space that would be deleted, without asking for confirmation and without deleting it")
+	addPropagationPolicyFlag(command, &propagationPolicy, "background")
+	return command
+}
+
+func DeleteSpace(ctx *clicontext.CommandContext, spaceName string, dryRun bool, propagationPolicy string) error {
+	propagation, err := deletionPropagation(propagationPolicy)
+	if err != nil {
+		return err
+	}
+	cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
+	if err != nil {
+		return err
+	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
+	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
+	if err != nil {
+		return err
+	}
+	space, err := client.GetSpace(ctx, cl, cfg.OperatorNamespace, spaceName)
+	if err != nil {
+		return err
+	}
+
+	namespaces := make([]string, 0, len(space.Status.ProvisionedNamespaces))
+	for _, ns := range space.Status.ProvisionedNamespaces {
+		namespaces = append(namespaces, ns.Name)
+	}
+	if dryRun {
+		ctx.Printlnf("DRY RUN: the Space '%s' would be deleted along with its namespaces: %s", spaceName, strings.Join(namespaces, ", "))
+		return nil
+	}
+
+	if err := ctx.PrintObject(space, "Space to be deleted"); err != nil {
+		return err
+	}
+	confirmation := ctx.AskForTypedConfirmation(ioutils.WithDangerZoneMessagef(
+		"deletion of all the namespaces of the Space and all related data",
+		"delete the Space '%s'?", spaceName), spaceName)
+	if !confirmation {
+		return nil
+	}
+
+	if err := cl.Delete(ctx, space, runtimeclient.PropagationPolicy(propagation)); err != nil {
+		return err
+	}
+	ctx.Printlnf("\nThe deletion of the Space '%s' has been triggered", spaceName)
+	return nil
+}
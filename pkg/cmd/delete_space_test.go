@@ -0,0 +1,130 @@
+package cmd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codeready-toolchain/toolchain-common/pkg/test"
+	testspace "github.com/codeready-toolchain/toolchain-common/pkg/test/space"
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDeleteSpace(t *testing.T) {
+	// given
+	SetFileConfig(t, Host())
+
+	t.Run("when the Space name is typed", func(t *testing.T) {
+		// given
+		space := newIdentitySpace("john", "member-1", "john-dev", "john-stage")
+		newClient, fakeClient := NewFakeClients(t, space)
+		term := NewFakeTerminalWithResponse("john")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DeleteSpace(ctx, "john", false, "background")
+
+		// then
+		require.NoError(t, err)
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).DoesNotExist()
+		assert.Contains(t, term.Output(), "!!!  DANGER ZONE  !!!")
+		assert.Contains(t, term.Output(), "Are you sure that you want to delete the Space 'john'?")
+		assert.Contains(t, term.Output(), "The deletion of the Space 'john' has been triggered")
+		assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
+		assert.NotContains(t, term.Output(), "cool-token")
+	})
+
+	t.Run("when answer does not match the Space name", func(t *testing.T) {
+		// given
+		space := newIdentitySpace("john", "member-1", "john-dev")
+		newClient, fakeClient := NewFakeClients(t, space)
+		term := NewFakeTerminalWithResponse("y") // a simple 'yes' is not enough
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DeleteSpace(ctx, "john", false, "background")
+
+		// then
+		require.NoError(t, err)
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).Exists()
+		assert.Contains(t, term.Output(), "Are you sure that you want to delete the Space 'john'?")
+		assert.Contains(t, term.Output(), "the response does not match 'john', aborting")
+		assert.NotContains(t, term.Output(), "has been triggered")
+	})
+
+	t.Run("with dry run", func(t *testing.T) {
+		// given
+		space := newIdentitySpace("john", "member-1", "john-dev", "john-stage")
+		newClient, fakeClient := NewFakeClients(t, space)
+		term := NewFakeTerminalWithResponse("") // it should not read the input
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DeleteSpace(ctx, "john", true, "background")
+
+		// then
+		require.NoError(t, err)
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).Exists()
+		assert.Contains(t, term.Output(), "DRY RUN: the Space 'john' would be deleted along with its namespaces: john-dev, john-stage")
+		assert.NotContains(t, term.Output(), "Are you sure")
+	})
+
+	t.Run("with propagation policy", func(t *testing.T) {
+		// given
+		space := newIdentitySpace("john", "member-1", "john-dev")
+		newClient, fakeClient := NewFakeClients(t, space)
+		var propagation *metav1.DeletionPropagation
+		fakeClient.MockDelete = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.DeleteOption) error {
+			deleteOptions := &runtimeclient.DeleteOptions{}
+			deleteOptions.ApplyOptions(opts)
+			propagation = deleteOptions.PropagationPolicy
+			return fakeClient.Client.Delete(ctx, obj, opts...)
+		}
+		term := NewFakeTerminalWithResponse("john")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DeleteSpace(ctx, "john", false, "foreground")
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, propagation)
+		assert.Equal(t, metav1.DeletePropagationForeground, *propagation)
+	})
+
+	t.Run("with unsupported propagation policy", func(t *testing.T) {
+		// given
+		space := newIdentitySpace("john", "member-1", "john-dev")
+		newClient, fakeClient := NewFakeClients(t, space)
+		term := NewFakeTerminalWithResponse("john")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DeleteSpace(ctx, "john", false, "cascade")
+
+		// then
+		require.EqualError(t, err, "unsupported propagation policy 'cascade', the supported ones are: foreground, background, orphan")
+		testspace.AssertThatSpace(t, test.HostOperatorNs, "john", fakeClient).Exists()
+		assert.NotContains(t, term.Output(), "Are you sure")
+	})
+
+	t.Run("when Space does not exist", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t)
+		term := NewFakeTerminalWithResponse("john")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DeleteSpace(ctx, "john", false, "background")
+
+		// then
+		require.EqualError(t, err, `spaces.toolchain.dev.openshift.com "john" not found`)
+		assert.NotContains(t, term.Output(), "Are you sure")
+	})
+}
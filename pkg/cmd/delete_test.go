@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/codeready-toolchain/toolchain-common/pkg/test/masteruserrecord"
 	"github.com/kubesaw/ksctl/pkg/cmd"
 	clicontext "github.com/kubesaw/ksctl/pkg/context"
 	. "github.com/kubesaw/ksctl/pkg/test"
@@ -14,16 +15,18 @@ import (
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func TestDeleteCmdWhenAnswerIsY(t *testing.T) {
+func TestDeleteCmdWhenNameIsTyped(t *testing.T) {
 	// given
-	userSignup := NewUserSignup()
-	newClient, fakeClient := NewFakeClients(t, userSignup)
+	userSignup := NewUserSignup(UserSignupCompliantUsername("johny"))
+	mur := masteruserrecord.NewMasterUserRecord(t, "johny")
+	space := newIdentitySpace("johny", "member-1", "johny-dev")
+	newClient, fakeClient := NewFakeClients(t, userSignup, mur, space)
 	SetFileConfig(t, Host())
-	term := NewFakeTerminalWithResponse("y")
+	term := NewFakeTerminalWithResponse(userSignup.Name)
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
-	err := cmd.Delete(ctx, userSignup.Name)
+	err := cmd.Delete(ctx, userSignup.Name, false, "foreground")
 
 	// then
 	require.NoError(t, err)
@@ -31,10 +34,55 @@ func TestDeleteCmdWhenAnswerIsY(t *testing.T) {
 	assert.Contains(t, term.Output(), "!!!  DANGER ZONE  !!!")
 	assert.Contains(t, term.Output(), "THIS COMMAND SHOULD BE EXECUTED BASED ON GDPR REQUEST.")
 	assert.Contains(t, term.Output(), "Are you sure that you want to delete the UserSignup above?")
+	assert.Contains(t, term.Output(), "type '"+userSignup.Name+"' to confirm")
+	assert.Contains(t, term.Output(), "The following resources will be deleted")
+	assert.Contains(t, term.Output(), "- MasterUserRecord 'johny'\n- Space 'johny'\n  - Namespace 'johny-dev' in cluster 'member-1'\n")
 	assert.Contains(t, term.Output(), "The deletion of the UserSignup has been triggered")
 	assert.NotContains(t, term.Output(), "cool-token")
 }
 
+func TestDeleteCmdWhenAnswerIsY(t *testing.T) {
+	// given
+	userSignup := NewUserSignup()
+	newClient, fakeClient := NewFakeClients(t, userSignup)
+	SetFileConfig(t, Host())
+	term := NewFakeTerminalWithResponse("y") // the name of the UserSignup is expected
+	ctx := clicontext.NewCommandContext(term, newClient)
+
+	// when
+	err := cmd.Delete(ctx, userSignup.Name, false, "foreground")
+
+	// then
+	require.NoError(t, err)
+	AssertUserSignupSpec(t, fakeClient, userSignup)
+	assert.Contains(t, term.Output(), "the response does not match '"+userSignup.Name+"', aborting")
+	assert.NotContains(t, term.Output(), "The deletion of the UserSignup has been triggered")
+}
+
+func TestDeleteCmdWithDryRun(t *testing.T) {
+	// given
+	userSignup := NewUserSignup(UserSignupCompliantUsername("johny"))
+	mur := masteruserrecord.NewMasterUserRecord(t, "johny")
+	space := newIdentitySpace("johny", "member-1", "johny-dev", "johny-stage")
+	newClient, fakeClient := NewFakeClients(t, userSignup, mur, space)
+	SetFileConfig(t, Host())
+	term := NewFakeTerminalWithResponse("") // it should not read the input
+	ctx := clicontext.NewCommandContext(term, newClient)
+
+	// when
+	err := cmd.Delete(ctx, userSignup.Name, true, "foreground")
+
+	// then
+	require.NoError(t, err)
+	AssertUserSignupSpec(t, fakeClient, userSignup)
+	output := term.Output()
+	assert.Contains(t, output, "DRY RUN: the following resources would be deleted")
+	assert.Contains(t, output, "- UserSignup '"+userSignup.Name+"'\n- MasterUserRecord 'johny'\n- Space 'johny'\n"+
+		"  - Namespace 'johny-dev' in cluster 'member-1'\n  - Namespace 'johny-stage' in cluster 'member-1'\n")
+	assert.NotContains(t, output, "Are you sure that you want to delete the UserSignup above?")
+	assert.NotContains(t, output, "The deletion of the UserSignup has been triggered")
+}
+
 func TestDeleteCmdWhenAnswerIsN(t *testing.T) {
 	// given
 	userSignup := NewUserSignup()
@@ -44,7 +92,7 @@ func TestDeleteCmdWhenAnswerIsN(t *testing.T) {
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
-	err := cmd.Delete(ctx, userSignup.Name)
+	err := cmd.Delete(ctx, userSignup.Name, false, "foreground")
 
 	// then
 	require.NoError(t, err)
@@ -65,7 +113,7 @@ func TestDeleteCmdWhenNotFound(t *testing.T) {
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
-	err := cmd.Delete(ctx, "some")
+	err := cmd.Delete(ctx, "some", false, "foreground")
 
 	// then
 	require.EqualError(t, err, "usersignups.toolchain.dev.openshift.com \"some\" not found")
@@ -86,7 +134,7 @@ func TestDeleteLacksPermissions(t *testing.T) {
 	ctx := clicontext.NewCommandContext(term, newClient)
 
 	// when
-	err := cmd.Delete(ctx, userSignup.Name)
+	err := cmd.Delete(ctx, userSignup.Name, false, "foreground")
 
 	// then
 	require.EqualError(t, err, "ksctl command failed: the token in your ksctl.yaml file is missing")
@@ -94,29 +142,51 @@ func TestDeleteLacksPermissions(t *testing.T) {
 }
 
 func TestDeleteHasPropagationPolicy(t *testing.T) {
-	// given
-	userSignup := NewUserSignup()
-	newClient, fakeClient := NewFakeClients(t, userSignup)
-	SetFileConfig(t, Host())
-	term := NewFakeTerminalWithResponse("y")
-
-	deleted := false
-	fakeClient.MockDelete = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.DeleteOption) error {
-		deleted = true
-		require.Len(t, opts, 1)
-		deleteOptions, ok := opts[0].(*runtimeclient.DeleteOptions)
-		require.True(t, ok)
-		require.NotNil(t, deleteOptions)
-		require.NotNil(t, deleteOptions.PropagationPolicy)
-		assert.Equal(t, metav1.DeletePropagationForeground, *deleteOptions.PropagationPolicy)
-		return nil
+	for policy, expected := range map[string]metav1.DeletionPropagation{
+		"foreground": metav1.DeletePropagationForeground,
+		"background": metav1.DeletePropagationBackground,
+		"Orphan":     metav1.DeletePropagationOrphan,
+	} {
+		t.Run(policy, func(t *testing.T) {
+			// given
+			userSignup := NewUserSignup()
+			newClient, fakeClient := NewFakeClients(t, userSignup)
+			SetFileConfig(t, Host())
+			term := NewFakeTerminalWithResponse(userSignup.Name)
+			deleted := false
+			fakeClient.MockDelete = func(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.DeleteOption) error {
+				deleted = true
+				deleteOptions := &runtimeclient.DeleteOptions{}
+				deleteOptions.ApplyOptions(opts)
+				require.NotNil(t, deleteOptions.PropagationPolicy)
+				assert.Equal(t, expected, *deleteOptions.PropagationPolicy)
+				return nil
+			}
+			ctx := clicontext.NewCommandContext(term, newClient)
+
+			// when
+			err := cmd.Delete(ctx, userSignup.Name, false, policy)
+
+			// then
+			require.NoError(t, err)
+			require.True(t, deleted)
+		})
 	}
-	ctx := clicontext.NewCommandContext(term, newClient)
 
-	// when
-	err := cmd.Delete(ctx, userSignup.Name)
-
-	// then
-	require.NoError(t, err)
-	require.True(t, deleted)
+	t.Run("unsupported policy", func(t *testing.T) {
+		// given
+		userSignup := NewUserSignup()
+		newClient, fakeClient := NewFakeClients(t, userSignup)
+		SetFileConfig(t, Host())
+		term := NewFakeTerminalWithResponse(userSignup.Name)
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.Delete(ctx, userSignup.Name, false, "cascade")
+
+		// then
+		require.EqualError(t, err, "unsupported propagation policy 'cascade', the supported ones are: foreground, background, orphan")
+		AssertUserSignupSpec(t, fakeClient, userSignup)
+		assert.NotContains(t, term.Output(), "Are you sure")
+	})
 }
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"strings"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/kubesaw/ksctl/pkg/client"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewDescribeUserCmd() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "describe-user <usersignup-name>",
+		Short: "Show the state and the provisioned resources of the user of the given UserSignup",
+		Long: `Show the state (approved, deactivated, banned...), the target cluster, the tier and the namespaces of the user
+of the given UserSignup, as recorded in its MasterUserRecord and Space. There is expected only one parameter
+which is the name of the UserSignup`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return DescribeUser(ctx, args[0], output)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: yaml")
+	return command
+}
+
+// UserDescription the state and the provisioned resources of the user of a UserSignup
+type UserDescription struct {
+	UserSignup       string   `json:"userSignup"`
+	State            string   `json:"state"`
+	MasterUserRecord string   `json:"masterUserRecord,omitempty"`
+	TargetCluster    string   `json:"targetCluster,omitempty"`
+	Tier             string   `json:"tier,omitempty"`
+	Namespaces       []string `json:"namespaces"`
+}
+
+func DescribeUser(ctx *clicontext.CommandContext, userSignupName, output string) error {
+	if err := ioutils.ValidateOutputFormatOneOf(output, "yaml"); err != nil {
+		return err
+	}
+	user, err := resolveProvisionedUser(ctx, userSignupName)
+	if err != nil {
+		return err
+	}
+
+	description := UserDescription{
+		UserSignup:    user.userSignup.Name,
+		State:         userSignupState(user.userSignup),
+		TargetCluster: user.targetCluster(),
+		Namespaces:    user.namespaces(),
+	}
+	if user.mur != nil {
+		description.MasterUserRecord = user.mur.Name
+		description.Tier = user.mur.Spec.TierName
+	}
+
+	if output == "yaml" {
+		return ioutils.PrintYAML(ctx, description)
+	}
+	ctx.Printlnf("UserSignup:        %s", description.UserSignup)
+	ctx.Printlnf("State:             %s", description.State)
+	if description.MasterUserRecord == "" {
+		ctx.Println("MasterUserRecord:  <not provisioned>")
+		return nil
+	}
+	ctx.Printlnf("MasterUserRecord:  %s", description.MasterUserRecord)
+	ctx.Printlnf("Target cluster:    %s", description.TargetCluster)
+	ctx.Printlnf("Tier:              %s", description.Tier)
+	namespaces := "<none>"
+	if len(description.Namespaces) > 0 {
+		namespaces = strings.Join(description.Namespaces, ", ")
+	}
+	ctx.Printlnf("Namespaces:        %s", namespaces)
+	return nil
+}
+
+// userSignupState returns the state of the given UserSignup, as set by the host operator in its state label
+func userSignupState(userSignup *toolchainv1alpha1.UserSignup) string {
+	if state := userSignup.Labels[toolchainv1alpha1.UserSignupStateLabelKey]; state != "" {
+		return state
+	}
+	return toolchainv1alpha1.UserSignupStateLabelValueNotReady
+}
@@ -0,0 +1,110 @@
+package cmd_test
+
+import (
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test/masteruserrecord"
+	"github.com/ghodss/yaml"
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeUser(t *testing.T) {
+	// given
+	SetFileConfig(t, Host())
+	userSignup := NewUserSignup(UserSignupCompliantUsername("johny"), UserSignupApprovedByAdmin(true))
+	mur := masteruserrecord.NewMasterUserRecord(t, "johny", masteruserrecord.TargetCluster("member-1"))
+	space := newIdentitySpace("johny", "member-1", "johny-dev", "johny-stage")
+
+	t.Run("as text", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, userSignup, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DescribeUser(ctx, userSignup.Name, "")
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "UserSignup:        "+userSignup.Name)
+		assert.Contains(t, output, "State:             approved")
+		assert.Contains(t, output, "MasterUserRecord:  johny")
+		assert.Contains(t, output, "Target cluster:    member-1")
+		assert.Contains(t, output, "Tier:              deactivate30")
+		assert.Contains(t, output, "Namespaces:        johny-dev, johny-stage")
+		assert.NotContains(t, output, "cool-token")
+	})
+
+	t.Run("as yaml", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, userSignup, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DescribeUser(ctx, userSignup.Name, "yaml")
+
+		// then
+		require.NoError(t, err)
+		description := cmd.UserDescription{}
+		require.NoError(t, yaml.Unmarshal([]byte(term.Output()), &description))
+		assert.Equal(t, cmd.UserDescription{
+			UserSignup:       userSignup.Name,
+			State:            "approved",
+			MasterUserRecord: "johny",
+			TargetCluster:    "member-1",
+			Tier:             "deactivate30",
+			Namespaces:       []string{"johny-dev", "johny-stage"},
+		}, description)
+	})
+
+	t.Run("when the user is deactivated", func(t *testing.T) {
+		// given
+		userSignup := NewUserSignup(UserSignupCompliantUsername("johny"), UserSignupDeactivated(true),
+			UserSignupSetLabel(toolchainv1alpha1.UserSignupStateLabelKey, toolchainv1alpha1.UserSignupStateLabelValueDeactivated))
+		newClient, _ := NewFakeClients(t, userSignup)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DescribeUser(ctx, userSignup.Name, "")
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "State:             deactivated")
+		assert.Contains(t, term.Output(), "MasterUserRecord:  <not provisioned>")
+	})
+
+	t.Run("when UserSignup does not exist", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DescribeUser(ctx, "unknown", "")
+
+		// then
+		require.EqualError(t, err, "usersignups.toolchain.dev.openshift.com \"unknown\" not found")
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, userSignup, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.DescribeUser(ctx, userSignup.Name, "json")
+
+		// then
+		require.EqualError(t, err, "unsupported output format 'json', the only supported format is 'yaml'")
+	})
+}
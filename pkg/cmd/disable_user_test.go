@@ -32,6 +32,7 @@ func TestDisableUserCmdWhenAnswerIsY(t *testing.T) {
 	assertMasterUserRecordSpec(t, fakeClient, mur1)
 	assert.Contains(t, term.Output(), "!!!  DANGER ZONE  !!!")
 	assert.Contains(t, term.Output(), "Are you sure that you want to disable the MasterUserRecord above?")
+	assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 	assert.Contains(t, term.Output(), "MasterUserRecord has been disabled")
 	assert.NotContains(t, term.Output(), "cool-token")
 }
@@ -15,3 +15,9 @@ func MustMarkRequired(cmd *cobra.Command, name string) {
 		panic(err)
 	}
 }
+
+func MustRegisterCompletionFunc(cmd *cobra.Command, name string, f func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)) {
+	if err := cmd.RegisterFlagCompletionFunc(name, f); err != nil {
+		panic(err)
+	}
+}
@@ -43,7 +43,7 @@ func NewCliConfigsCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			return generate(term, f, DefaultNewExternalClientFromConfig)
+			return generate(cmd.Context(), term, f, DefaultNewExternalClientFromConfig)
 		},
 	}
 	command.Flags().StringVarP(&f.kubeSawAdminsFile, "kubesaw-admins", "c", "", "Use the given kubesaw-admin file")
@@ -78,7 +78,7 @@ var DefaultNewExternalClientFromConfig = func(config *rest.Config) (*rest.RESTCl
 	return rest.RESTClientFor(config)
 }
 
-func generate(term ioutils.Terminal, flags generateFlags, newExternalClient NewRESTClientFromConfigFunc) error {
+func generate(cmdCtx context.Context, term ioutils.Terminal, flags generateFlags, newExternalClient NewRESTClientFromConfigFunc) error {
 	if err := client.AddToScheme(); err != nil {
 		return err
 	}
@@ -90,6 +90,7 @@ func generate(term ioutils.Terminal, flags generateFlags, newExternalClient NewR
 	}
 
 	ctx := &generateContext{
+		Context:             cmdCtx,
 		Terminal:            term,
 		newRESTClient:       newExternalClient,
 		kubeSawAdmins:       kubeSawAdmins,
@@ -161,6 +162,7 @@ func writeKsctlConfigs(term ioutils.Terminal, configDirPath string, ksctlConfigs
 }
 
 type generateContext struct {
+	context.Context
 	ioutils.Terminal
 	newRESTClient       NewRESTClientFromConfigFunc
 	kubeSawAdmins       *assets.KubeSawAdmins
@@ -198,7 +200,7 @@ func generateForCluster(ctx *generateContext, clusterType configuration.ClusterT
 				saNamespace = sa.Namespace
 			}
 			ctx.Printlnf("Getting token for SA '%s' in namespace '%s'", sa.Name, saNamespace)
-			token, err := getServiceAccountToken(externalClient, types.NamespacedName{
+			token, err := getServiceAccountToken(ctx, externalClient, types.NamespacedName{
 				Namespace: saNamespace,
 				Name:      sa.Name}, ctx.tokenExpirationDays)
 			if token == "" || err != nil {
@@ -232,7 +234,7 @@ func buildClientFromKubeconfigFiles(ctx *generateContext, API string, kubeconfig
 		sas := &v1.ServiceAccountList{}
 		if err := externalCl.Get().
 			AbsPath(fmt.Sprintf("api/v1/namespaces/%s/serviceaccounts/", saNamespace)).
-			Do(context.TODO()).Into(sas); err != nil {
+			Do(ctx).Into(sas); err != nil {
 			ctx.Printlnf("Unable to use restclient built with kubeconfig file located at '%s' for the cluster '%s': %s", kubeconfigPath, API, err.Error())
 			ctx.Printlnf("trying next one...")
 			continue
@@ -247,7 +249,7 @@ func buildClientFromKubeconfigFiles(ctx *generateContext, API string, kubeconfig
 // NOTE: due to a changes in OpenShift 4.11, tokens are not listed as `secrets` in ServiceAccounts.
 // The recommended solution is to use the TokenRequest API when server version >= 4.11
 // (see https://docs.openshift.com/container-platform/4.11/release_notes/ocp-4-11-release-notes.html#ocp-4-11-notable-technical-changes)
-func getServiceAccountToken(cl *rest.RESTClient, namespacedName types.NamespacedName, tokenExpirationDays uint) (string, error) {
+func getServiceAccountToken(ctx context.Context, cl *rest.RESTClient, namespacedName types.NamespacedName, tokenExpirationDays uint) (string, error) {
 	tokenRequest := &authv1.TokenRequest{
 		Spec: authv1.TokenRequestSpec{
 			ExpirationSeconds: pointer.Int64(int64(tokenExpirationDays * 24 * 60 * 60)),
@@ -257,7 +259,7 @@ func getServiceAccountToken(cl *rest.RESTClient, namespacedName types.Namespaced
 	if err := cl.Post().
 		AbsPath(fmt.Sprintf("api/v1/namespaces/%s/serviceaccounts/%s/token", namespacedName.Namespace, namespacedName.Name)).
 		Body(tokenRequest).
-		Do(context.TODO()).
+		Do(ctx).
 		Into(result); err != nil {
 		return "", err
 	}
@@ -1,6 +1,7 @@
 package generate
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -77,7 +78,7 @@ func TestGenerateCliConfigs(t *testing.T) {
 			flags := generateFlags{kubeconfigs: kubeconfigFiles, kubeSawAdminsFile: configFile, outDir: tempDir, tokenExpirationDays: 50}
 
 			// when
-			err = generate(term, flags, newExternalClient)
+			err = generate(context.TODO(), term, flags, newExternalClient)
 
 			// then
 			require.NoError(t, err)
@@ -105,7 +106,7 @@ func TestGenerateCliConfigs(t *testing.T) {
 			flags := generateFlags{kubeconfigs: kubeconfigFiles, kubeSawAdminsFile: configFile, outDir: tempDir, tokenExpirationDays: 50}
 
 			// when
-			err = generate(term, flags, newExternalClient)
+			err = generate(context.TODO(), term, flags, newExternalClient)
 
 			// then
 			require.NoError(t, err)
@@ -126,7 +127,7 @@ func TestGenerateCliConfigs(t *testing.T) {
 			flags := generateFlags{kubeconfigs: kubeconfigFiles, kubeSawAdminsFile: configFile, outDir: tempDir, dev: true, tokenExpirationDays: 50}
 
 			// when
-			err = generate(term, flags, newExternalClient)
+			err = generate(context.TODO(), term, flags, newExternalClient)
 
 			// then
 			require.NoError(t, err)
@@ -139,6 +140,7 @@ func TestGenerateCliConfigs(t *testing.T) {
 		t.Run("test buildClientFromKubeconfigFiles cannot build REST client", func(t *testing.T) {
 			// given
 			ctx := &generateContext{
+				Context:  context.TODO(),
 				Terminal: NewFakeTerminalWithResponse("y"),
 				newRESTClient: func(config *rest.Config) (*rest.RESTClient, error) {
 					return nil, fmt.Errorf("some error")
@@ -158,6 +160,7 @@ func TestGenerateCliConfigs(t *testing.T) {
 			path := fmt.Sprintf("api/v1/namespaces/%s/serviceaccounts/", sandboxSRENamespace(configuration.Host))
 			gock.New("https://dummy.openshift.com").Get(path).Persist().Reply(403)
 			ctx := &generateContext{
+				Context:             context.TODO(),
 				Terminal:            term,
 				newRESTClient:       newExternalClient,
 				kubeSawAdmins:       kubeSawAdmins,
@@ -180,7 +183,7 @@ func TestGenerateCliConfigs(t *testing.T) {
 			flags := generateFlags{kubeconfigs: kubeconfigFiles, kubeSawAdminsFile: "does/not/exist", outDir: tempDir}
 
 			// when
-			err = generate(term, flags, newExternalClient)
+			err = generate(context.TODO(), term, flags, newExternalClient)
 
 			// then
 			require.Error(t, err)
@@ -194,7 +197,7 @@ func TestGenerateCliConfigs(t *testing.T) {
 			flags := generateFlags{kubeconfigs: []string{"does/not/exist"}, kubeSawAdminsFile: configFile, outDir: tempDir}
 
 			// when
-			err = generate(term, flags, newExternalClient)
+			err = generate(context.TODO(), term, flags, newExternalClient)
 
 			// then
 			require.Error(t, err)
@@ -217,7 +220,7 @@ func TestGenerateCliConfigs(t *testing.T) {
 			flags := generateFlags{kubeconfigs: kubeconfigFiles, kubeSawAdminsFile: configFile, outDir: tempDir}
 
 			// when
-			err = generate(term, flags, newExternalClient)
+			err = generate(context.TODO(), term, flags, newExternalClient)
 
 			// then
 			require.ErrorContains(t, err, "notmocked/token\": gock: cannot match any request")
@@ -237,7 +240,7 @@ func TestGetServiceAccountToken(t *testing.T) {
 	// gock.Observe(gock.DumpRequest)
 	require.NoError(t, err)
 	// when
-	actualToken, err := getServiceAccountToken(cl, types.NamespacedName{
+	actualToken, err := getServiceAccountToken(context.TODO(), cl, types.NamespacedName{
 		Namespace: "openshift-customer-monitoring",
 		Name:      "loki",
 	}, 365)
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func NewGetIdentityCmd() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "get-identity <usersignup-name>",
+		Short: "Show where the user of the given UserSignup is provisioned",
+		Long: `Show the MasterUserRecord, the target cluster, the tier and the provisioned namespaces of the user
+of the given UserSignup. There is expected only one parameter which is the name of the UserSignup`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return GetIdentity(ctx, args[0], output)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json")
+	return command
+}
+
+// Identity where the user of a UserSignup is provisioned
+type Identity struct {
+	UserSignup       string   `json:"userSignup"`
+	MasterUserRecord string   `json:"masterUserRecord"`
+	Space            string   `json:"space"`
+	TargetCluster    string   `json:"targetCluster"`
+	Tier             string   `json:"tier"`
+	Namespaces       []string `json:"namespaces"`
+}
+
+func GetIdentity(ctx *clicontext.CommandContext, userSignupName, output string) error {
+	if err := ioutils.ValidateOutputFormat(output); err != nil {
+		return err
+	}
+	user, err := resolveProvisionedUser(ctx, userSignupName)
+	if err != nil {
+		return err
+	}
+	if user.mur == nil {
+		return fmt.Errorf("the UserSignup '%s' has not been provisioned yet", userSignupName)
+	}
+
+	identity := Identity{
+		UserSignup:       user.userSignup.Name,
+		MasterUserRecord: user.mur.Name,
+		TargetCluster:    user.targetCluster(),
+		Tier:             user.mur.Spec.TierName,
+		Namespaces:       user.namespaces(),
+	}
+	if user.space != nil {
+		identity.Space = user.space.Name
+		identity.Tier = user.space.Spec.TierName
+	}
+
+	if output == "json" {
+		return ioutils.PrintJSON(ctx, identity)
+	}
+	namespaces := "no provisioned namespace"
+	switch len(identity.Namespaces) {
+	case 0:
+	case 1:
+		namespaces = "namespace " + identity.Namespaces[0]
+	default:
+		namespaces = "namespaces " + strings.Join(identity.Namespaces, ", ")
+	}
+	ctx.Printlnf("user %s is on cluster %s with %s (tier %s)", identity.MasterUserRecord, identity.TargetCluster, namespaces, identity.Tier)
+	return nil
+}
+
+// provisionedUser the resources of the user of a UserSignup, shared by the get-identity and describe-user commands
+type provisionedUser struct {
+	userSignup *toolchainv1alpha1.UserSignup
+	// mur is nil when the user is not provisioned (yet, or any more)
+	mur *toolchainv1alpha1.MasterUserRecord
+	// space is nil when the home Space of the user does not exist (yet, or any more)
+	space *toolchainv1alpha1.Space
+}
+
+// resolveProvisionedUser returns the given UserSignup with the MasterUserRecord and the home Space of its user.
+// A missing MasterUserRecord or Space is not an error, since the user may not be provisioned (yet, or any more)
+func resolveProvisionedUser(ctx *clicontext.CommandContext, userSignupName string) (provisionedUser, error) {
+	cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
+	if err != nil {
+		return provisionedUser{}, err
+	}
+	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
+	if err != nil {
+		return provisionedUser{}, err
+	}
+	userSignup, err := client.GetUserSignup(ctx, cl, cfg.OperatorNamespace, userSignupName)
+	if err != nil {
+		return provisionedUser{}, err
+	}
+	user := provisionedUser{
+		userSignup: userSignup,
+	}
+	if userSignup.Status.CompliantUsername == "" {
+		return user, nil
+	}
+	mur, err := client.GetMasterUserRecord(ctx, cl, cfg.OperatorNamespace, userSignup.Status.CompliantUsername)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return provisionedUser{}, err
+	}
+	if err == nil {
+		user.mur = mur
+	}
+	spaceName := userSignup.Status.HomeSpace
+	if spaceName == "" {
+		spaceName = userSignup.Status.CompliantUsername
+	}
+	space, err := client.GetSpace(ctx, cl, cfg.OperatorNamespace, spaceName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return provisionedUser{}, err
+	}
+	if err == nil {
+		user.space = space
+	}
+	return user, nil
+}
+
+// targetCluster returns the target cluster of the Space or, when not set yet, the one of the MasterUserRecord
+func (u provisionedUser) targetCluster() string {
+	if u.space != nil && u.space.Status.TargetCluster != "" {
+		return u.space.Status.TargetCluster
+	}
+	if u.mur != nil && len(u.mur.Spec.UserAccounts) > 0 {
+		return u.mur.Spec.UserAccounts[0].TargetCluster
+	}
+	return ""
+}
+
+// namespaces returns the names of the namespaces provisioned for the Space (if any)
+func (u provisionedUser) namespaces() []string {
+	namespaces := []string{}
+	if u.space == nil {
+		return namespaces
+	}
+	for _, ns := range u.space.Status.ProvisionedNamespaces {
+		namespaces = append(namespaces, ns.Name)
+	}
+	return namespaces
+}
@@ -0,0 +1,152 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test/masteruserrecord"
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetIdentity(t *testing.T) {
+	// given
+	SetFileConfig(t, Host())
+	userSignup := NewUserSignup(UserSignupCompliantUsername("johny"))
+	mur := masteruserrecord.NewMasterUserRecord(t, "johny", masteruserrecord.TargetCluster("member-1"))
+	space := newIdentitySpace("johny", "member-2", "johny-dev", "johny-stage")
+
+	t.Run("as text", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, userSignup, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.GetIdentity(ctx, userSignup.Name, "")
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "user johny is on cluster member-2 with namespaces johny-dev, johny-stage (tier base)")
+		assert.NotContains(t, output, "cool-token")
+	})
+
+	t.Run("as json", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, userSignup, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.GetIdentity(ctx, userSignup.Name, "json")
+
+		// then
+		require.NoError(t, err)
+		identity := cmd.Identity{}
+		require.NoError(t, json.Unmarshal([]byte(term.Output()), &identity))
+		assert.Equal(t, cmd.Identity{
+			UserSignup:       userSignup.Name,
+			MasterUserRecord: "johny",
+			Space:            "johny",
+			TargetCluster:    "member-2",
+			Tier:             "base",
+			Namespaces:       []string{"johny-dev", "johny-stage"},
+		}, identity)
+	})
+
+	t.Run("target cluster from MasterUserRecord when Space is not provisioned yet", func(t *testing.T) {
+		// given
+		space := newIdentitySpace("johny", "")
+		newClient, _ := NewFakeClients(t, userSignup, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.GetIdentity(ctx, userSignup.Name, "")
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "user johny is on cluster member-1 with no provisioned namespace (tier base)")
+	})
+
+	t.Run("when UserSignup is not provisioned yet", func(t *testing.T) {
+		// given
+		userSignup := NewUserSignup()
+		newClient, _ := NewFakeClients(t, userSignup)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.GetIdentity(ctx, userSignup.Name, "")
+
+		// then
+		require.EqualError(t, err, "the UserSignup '"+userSignup.Name+"' has not been provisioned yet")
+	})
+
+	t.Run("when MasterUserRecord does not exist any more", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, userSignup, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.GetIdentity(ctx, userSignup.Name, "")
+
+		// then
+		require.EqualError(t, err, "the UserSignup '"+userSignup.Name+"' has not been provisioned yet")
+	})
+
+	t.Run("when UserSignup does not exist", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.GetIdentity(ctx, "unknown", "")
+
+		// then
+		require.EqualError(t, err, "usersignups.toolchain.dev.openshift.com \"unknown\" not found")
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, userSignup, mur, space)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.GetIdentity(ctx, userSignup.Name, "yaml")
+
+		// then
+		require.EqualError(t, err, "unsupported output format 'yaml', the only supported format is 'json'")
+	})
+}
+
+func newIdentitySpace(name, targetCluster string, namespaces ...string) *toolchainv1alpha1.Space {
+	space := &toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: test.HostOperatorNs,
+		},
+		Spec: toolchainv1alpha1.SpaceSpec{
+			TierName:      "base",
+			TargetCluster: targetCluster,
+		},
+		Status: toolchainv1alpha1.SpaceStatus{
+			TargetCluster: targetCluster,
+		},
+	}
+	for _, ns := range namespaces {
+		space.Status.ProvisionedNamespaces = append(space.Status.ProvisionedNamespaces, toolchainv1alpha1.SpaceNamespace{Name: ns})
+	}
+	return space
+}
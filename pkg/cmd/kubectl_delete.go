@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubectldelete "k8s.io/kubectl/pkg/cmd/delete"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// NewDeleteCmd returns the `delete` command which wraps `kubectl delete`, and which asks for a confirmation before deleting anything
+func NewDeleteCmd() *cobra.Command {
+	return setupKubectlCmd(func(factory cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+		return kubectldelete.NewCmdDelete(factory, ioStreams)
+	}, destructive())
+}
@@ -0,0 +1,127 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDelete(t *testing.T) {
+	// given
+	deletions := 0
+	server := newDeleteServer(t, &deletions)
+	defer server.Close()
+	SetFileConfig(t, Host(ServerAPI(server.URL)))
+	newDeleteCmd := func(answer string) (*bytes.Buffer, func() error) {
+		deleteCmd := cmd.NewDeleteCmd()
+		out := bytes.NewBuffer(nil)
+		deleteCmd.SetIn(strings.NewReader(answer + "\n"))
+		deleteCmd.SetOut(out)
+		deleteCmd.SetArgs([]string{
+			"-t=host",
+			"--insecure-skip-tls-verify=true",
+			"--wait=false",
+			"pods", "cheesecake",
+		})
+		return out, func() error {
+			_, err := deleteCmd.ExecuteC()
+			return err
+		}
+	}
+
+	t.Run("deletes when confirmed", func(t *testing.T) {
+		// given
+		deletions = 0
+		out, execute := newDeleteCmd("y")
+
+		// when
+		err := execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, deletions)
+		assert.Contains(t, out.String(), "!!!  DANGER ZONE  !!!")
+		assert.Contains(t, out.String(), "Are you sure that you want to run 'delete pods cheesecake' on the 'host' cluster?")
+		assert.Contains(t, out.String(), ">>> target cluster: 'host'")
+		assert.Contains(t, out.String(), `pod "cheesecake" deleted`)
+	})
+
+	t.Run("does not delete when declined", func(t *testing.T) {
+		// given
+		deletions = 0
+		out, execute := newDeleteCmd("n")
+
+		// when
+		err := execute()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 0, deletions)
+		assert.Contains(t, out.String(), "Are you sure that you want to run 'delete pods cheesecake' on the 'host' cluster?")
+		assert.NotContains(t, out.String(), "deleted")
+	})
+}
+
+func newDeleteServer(t *testing.T, deletions *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var response interface{}
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/api":
+			response = &metav1.APIVersions{
+				Versions: []string{"v1"},
+			}
+		case req.Method == "GET" && req.URL.Path == "/apis":
+			response = &metav1.APIGroupList{
+				Groups: []metav1.APIGroup{},
+			}
+		case req.Method == "GET" && req.URL.Path == "/api/v1":
+			response = &metav1.APIResourceList{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name:         "pods",
+						SingularName: "pod",
+						Namespaced:   true,
+						Kind:         "Pod",
+						Verbs:        []string{"delete", "get", "list"},
+					},
+				},
+			}
+		case req.Method == "DELETE" && req.URL.Path == "/api/v1/namespaces/toolchain-host-operator/pods/cheesecake":
+			*deletions++
+			response = &corev1.Pod{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "toolchain-host-operator",
+					Name:      "cheesecake",
+				},
+			}
+		default:
+			t.Errorf("unexpected request: %s %s\n", req.Method, req.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		output, err := json.Marshal(response)
+		if err != nil {
+			t.Errorf("unexpected encoding error: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(output) // nolint: errcheck
+	}))
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/condition"
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func NewListMemberStatusCmd() *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "list-memberstatus",
+		Short: "List the status of all member clusters",
+		Long: `List the status of all member clusters as reported in the ToolchainStatus CR of the host cluster:
+member name, API endpoint, number of Spaces, Ready condition and memory usage per node role`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return ListMemberStatus(ctx, output)
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json, custom-columns=<column>[,<column>...] (with the columns: "+strings.Join(memberStatusColumns, ", ")+")")
+	return command
+}
+
+// memberStatusColumns the columns which can be selected with the `-o custom-columns=...` output format
+var memberStatusColumns = []string{"name", "api-endpoint", "spaces", "ready", "reason", "memory-usage"}
+
+// MemberStatusSummary the status of a single member cluster
+type MemberStatusSummary struct {
+	ClusterName            string         `json:"clusterName"`
+	APIEndpoint            string         `json:"apiEndpoint"`
+	SpaceCount             int            `json:"spaceCount"`
+	Ready                  string         `json:"ready"`
+	Reason                 string         `json:"reason,omitempty"`
+	MemoryUsagePerNodeRole map[string]int `json:"memoryUsagePerNodeRole,omitempty"`
+}
+
+func ListMemberStatus(ctx *clicontext.CommandContext, output string) error {
+	columns, err := ioutils.CustomColumns(output, memberStatusColumns...)
+	if err != nil {
+		return err
+	}
+	if columns == nil {
+		if err := ioutils.ValidateOutputFormat(output); err != nil {
+			return err
+		}
+	}
+	cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
+	if err != nil {
+		return err
+	}
+	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
+	if err != nil {
+		return err
+	}
+	status, err := getToolchainStatus(ctx, cl, cfg.OperatorNamespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("the ToolchainStatus CR was not found in the '%s' namespace of the host cluster: %w", cfg.OperatorNamespace, err)
+		}
+		return err
+	}
+
+	summaries := make([]MemberStatusSummary, 0, len(status.Status.Members))
+	for _, member := range status.Status.Members {
+		summary := MemberStatusSummary{
+			ClusterName:            member.ClusterName,
+			APIEndpoint:            member.APIEndpoint,
+			SpaceCount:             member.SpaceCount,
+			Ready:                  "Unknown",
+			MemoryUsagePerNodeRole: member.MemberStatus.ResourceUsage.MemoryUsagePerNodeRole,
+		}
+		if cond, exists := condition.FindConditionByType(member.MemberStatus.Conditions, toolchainv1alpha1.ConditionReady); exists {
+			summary.Ready = string(cond.Status)
+			summary.Reason = cond.Reason
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ClusterName < summaries[j].ClusterName
+	})
+
+	if output == "json" {
+		return ioutils.PrintJSON(ctx, summaries)
+	}
+	if len(summaries) == 0 {
+		ctx.Println("There is no member cluster in the ToolchainStatus CR")
+		return nil
+	}
+	if columns != nil {
+		return ioutils.PrintColumns(ctx, columns, len(summaries), func(row int, column string) string {
+			s := summaries[row]
+			switch column {
+			case "api-endpoint":
+				return s.APIEndpoint
+			case "spaces":
+				return fmt.Sprint(s.SpaceCount)
+			case "ready":
+				return s.Ready
+			case "reason":
+				return s.Reason
+			case "memory-usage":
+				return formatMemoryUsage(s.MemoryUsagePerNodeRole)
+			default:
+				return s.ClusterName
+			}
+		})
+	}
+	w := tabwriter.NewWriter(ctx.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "MEMBER\tAPI ENDPOINT\tSPACES\tREADY\tREASON\tMEMORY USAGE")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", s.ClusterName, s.APIEndpoint, s.SpaceCount, s.Ready, s.Reason, formatMemoryUsage(s.MemoryUsagePerNodeRole))
+	}
+	return w.Flush()
+}
+
+func formatMemoryUsage(usage map[string]int) string {
+	if len(usage) == 0 {
+		return "-"
+	}
+	roles := make([]string, 0, len(usage))
+	for role := range usage {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	values := make([]string, 0, len(roles))
+	for _, role := range roles {
+		values = append(values, fmt.Sprintf("%s:%d%%", role, usage[role]))
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,139 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListMemberStatus(t *testing.T) {
+	// given
+	toolchainStatus := NewToolchainStatus(ToBeReady())
+	toolchainStatus.Status.Members = []toolchainv1alpha1.Member{
+		{
+			ClusterName: "member-2",
+			APIEndpoint: "https://api.member-2.com:6443",
+			SpaceCount:  3,
+			MemberStatus: toolchainv1alpha1.MemberStatusStatus{
+				Conditions: []toolchainv1alpha1.Condition{ToBeNotReady()},
+			},
+		},
+		{
+			ClusterName: "member-1",
+			APIEndpoint: "https://api.member-1.com:6443",
+			SpaceCount:  10,
+			MemberStatus: toolchainv1alpha1.MemberStatusStatus{
+				Conditions: []toolchainv1alpha1.Condition{ToBeReady()},
+				ResourceUsage: toolchainv1alpha1.ResourceUsage{
+					MemoryUsagePerNodeRole: map[string]int{
+						"worker": 60,
+						"master": 40,
+					},
+				},
+			},
+		},
+	}
+	SetFileConfig(t, Host())
+
+	t.Run("as table", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, toolchainStatus)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.ListMemberStatus(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		output := term.Output()
+		assert.Contains(t, output, "member-1   https://api.member-1.com:6443   10       True    AllComponentsReady   master:40%,worker:60%")
+		assert.Contains(t, output, "member-2   https://api.member-2.com:6443   3        False   ComponentsNotReady   -")
+		assert.Less(t, strings.Index(output, "member-1"), strings.Index(output, "member-2"))
+		assert.NotContains(t, output, "cool-token")
+	})
+
+	t.Run("with custom columns", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, toolchainStatus)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.ListMemberStatus(ctx, "custom-columns=name,spaces,ready")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "NAME       SPACES   READY\n"+
+			"member-1   10       True\n"+
+			"member-2   3        False\n", term.Output())
+	})
+
+	t.Run("as json", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, toolchainStatus)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.ListMemberStatus(ctx, "json")
+
+		// then
+		require.NoError(t, err)
+		var summaries []cmd.MemberStatusSummary
+		require.NoError(t, json.Unmarshal([]byte(term.Output()), &summaries))
+		assert.Equal(t, []cmd.MemberStatusSummary{
+			{
+				ClusterName:            "member-1",
+				APIEndpoint:            "https://api.member-1.com:6443",
+				SpaceCount:             10,
+				Ready:                  "True",
+				Reason:                 "AllComponentsReady",
+				MemoryUsagePerNodeRole: map[string]int{"worker": 60, "master": 40},
+			},
+			{
+				ClusterName: "member-2",
+				APIEndpoint: "https://api.member-2.com:6443",
+				SpaceCount:  3,
+				Ready:       "False",
+				Reason:      "ComponentsNotReady",
+			},
+		}, summaries)
+	})
+
+	t.Run("without members", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t, NewToolchainStatus(ToBeReady()))
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.ListMemberStatus(ctx, "")
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "There is no member cluster in the ToolchainStatus CR")
+	})
+
+	t.Run("when ToolchainStatus is missing", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.ListMemberStatus(ctx, "")
+
+		// then
+		require.EqualError(t, err, "the ToolchainStatus CR was not found in the 'toolchain-host-operator' namespace of the host cluster: "+
+			"toolchainstatuses.toolchain.dev.openshift.com \"toolchain-status\" not found")
+	})
+}
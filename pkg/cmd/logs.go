@@ -1,14 +1,351 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	kubectllogs "k8s.io/kubectl/pkg/cmd/logs"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
 
 func NewLogsCmd() *cobra.Command {
-	return setupKubectlCmd(func(factory cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	jsonLogs := &jsonLogsOptions{}
+	var out *jsonLogsWriter
+	var allNamespaces bool
+	var kubeFactory cmdutil.Factory
+	cmd := setupKubectlCmd(func(factory cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+		kubeFactory = factory
+		out = &jsonLogsWriter{
+			options: jsonLogs,
+			out:     ioStreams.Out,
+		}
+		ioStreams.Out = out
 		return kubectllogs.NewCmdLogs(factory, ioStreams)
 	})
+	cmd.Flags().BoolVar(&jsonLogs.enabled, "json-logs", false, "Parse the operator JSON logs and print them as 'LEVEL TIME msg key=value ...'. Non-JSON lines are printed unchanged.")
+	cmd.Flags().StringVar(&jsonLogs.grep, "grep", "", "Only print the JSON log entries whose message contains the given text (requires '--json-logs')")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Print the logs of the pods matching the selector in all the namespaces of the cluster, "+
+		"each line being prefixed with '[<namespace>/<pod>/<container>]'. The number of lines per container is bounded by '--tail'. Cannot be used with a pod name nor with '--follow'")
+	cmd.Flags().StringVar(&jsonLogs.level, "level", "", fmt.Sprintf("Only print the JSON log entries with the given severity or higher, one of: %s (requires '--json-logs')", strings.Join(logLevels, ", ")))
+
+	cmd.Long += fmt.Sprintf(`
+
+If no pod name nor selector is provided, then the logs of the operator pods (with the '%s' label) are printed.
+Unless specified otherwise, only the last %d lines (see '--tail') of the logs of the last %s (see '--since') are printed.
+Use '--tail=-1' and '--since=0' to print all the logs.`, operatorPodsSelector, defaultLogsTail, defaultLogsSince)
+
+	preRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := jsonLogs.validate(); err != nil {
+			return err
+		}
+		if allNamespaces {
+			if len(args) > 0 {
+				return fmt.Errorf("a pod name cannot be given along with the '--all-namespaces' flag, use a selector instead")
+			}
+			if follow, _ := cmd.Flags().GetBool("follow"); follow {
+				return fmt.Errorf("the '--follow' flag cannot be used along with the '--all-namespaces' flag")
+			}
+		}
+		if len(args) == 0 && !cmd.Flag("selector").Changed {
+			// default to the operator pods
+			if err := cmd.Flags().Set("selector", operatorPodsSelector); err != nil {
+				return err
+			}
+		}
+		if !cmd.Flag("tail").Changed {
+			if err := cmd.Flags().Set("tail", strconv.Itoa(defaultLogsTail)); err != nil {
+				return err
+			}
+		}
+		if !cmd.Flag("since").Changed && !cmd.Flag("since-time").Changed {
+			if err := cmd.Flags().Set("since", defaultLogsSince); err != nil {
+				return err
+			}
+		}
+		return preRunE(cmd, args)
+	}
+	run := cmd.Run
+	cmd.Run = nil
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if allNamespaces {
+			return printAllNamespacesLogs(cmd, kubeFactory, jsonLogs)
+		}
+		run(cmd, args)
+		return nil
+	}
+	cmd.PostRunE = func(cmd *cobra.Command, args []string) error {
+		return out.Flush()
+	}
+	return cmd
+}
+
+// printAllNamespacesLogs prints the logs of all the containers of the pods matching the selector in all the namespaces,
+// one container after the other, with each line prefixed with the namespace, the pod and the container it comes from
+func printAllNamespacesLogs(cmd *cobra.Command, factory cmdutil.Factory, jsonLogs *jsonLogsOptions) error {
+	logOptions, err := podLogOptions(cmd)
+	if err != nil {
+		return err
+	}
+	clientset, err := factory.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	selector, err := cmd.Flags().GetString("selector")
+	if err != nil {
+		return err
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		if pods.Items[i].Namespace != pods.Items[j].Namespace {
+			return pods.Items[i].Namespace < pods.Items[j].Namespace
+		}
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	container, err := cmd.Flags().GetString("container")
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers {
+			if container != "" && c.Name != container {
+				continue
+			}
+			opts := logOptions.DeepCopy()
+			opts.Container = c.Name
+			stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+			if err != nil {
+				return err
+			}
+			err = printPrefixedLogs(stream, cmd.OutOrStdout(), fmt.Sprintf("[%s/%s/%s] ", pod.Namespace, pod.Name, c.Name), jsonLogs)
+			stream.Close() // nolint: errcheck
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// podLogOptions returns the options of the requests of the logs, as set with the flags of the command
+func podLogOptions(cmd *cobra.Command) (*corev1.PodLogOptions, error) {
+	opts := &corev1.PodLogOptions{}
+	if tail, err := cmd.Flags().GetInt64("tail"); err != nil {
+		return nil, err
+	} else if tail >= 0 {
+		opts.TailLines = &tail
+	}
+	if since, err := cmd.Flags().GetDuration("since"); err != nil {
+		return nil, err
+	} else if since > 0 {
+		sinceSeconds := int64(since.Round(time.Second).Seconds())
+		opts.SinceSeconds = &sinceSeconds
+	}
+	if sinceTime, err := cmd.Flags().GetString("since-time"); err != nil {
+		return nil, err
+	} else if sinceTime != "" {
+		t, err := time.Parse(time.RFC3339, sinceTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '--since-time' value '%s': %w", sinceTime, err)
+		}
+		opts.SinceTime = &metav1.Time{Time: t}
+	}
+	return opts, nil
+}
+
+// printPrefixedLogs prints all the lines read from the given logs with the given prefix,
+// after rendering them when the `--json-logs` flag is set
+func printPrefixedLogs(logs io.Reader, out io.Writer, prefix string, jsonLogs *jsonLogsOptions) error {
+	w := &jsonLogsWriter{
+		options: jsonLogs,
+		out: writerFunc(func(p []byte) (int, error) {
+			if _, err := io.WriteString(out, prefix); err != nil {
+				return 0, err
+			}
+			return out.Write(p)
+		}),
+	}
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if _, err := w.Write(append(scanner.Bytes(), '\n')); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// operatorPodsSelector the label selector of the pods of the toolchain operators
+const operatorPodsSelector = "provider=codeready-toolchain"
+
+const (
+	// defaultLogsTail the number of lines printed when the '--tail' flag is not set
+	defaultLogsTail = 200
+	// defaultLogsSince the duration of logs printed when neither the '--since' nor the '--since-time' flag is set
+	defaultLogsSince = "1h"
+)
+
+// logLevels the known severities of the operator logs, from the lowest to the highest
+var logLevels = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+
+func logLevelRank(level string) int {
+	for i, l := range logLevels {
+		if strings.EqualFold(l, level) {
+			return i
+		}
+	}
+	return -1
+}
+
+type jsonLogsOptions struct {
+	enabled bool
+	grep    string
+	level   string
+}
+
+func (o *jsonLogsOptions) validate() error {
+	if !o.enabled && (o.grep != "" || o.level != "") {
+		return fmt.Errorf("the '--grep' and '--level' flags can only be used along with the '--json-logs' flag")
+	}
+	if o.level != "" && logLevelRank(o.level) < 0 {
+		return fmt.Errorf("invalid log level '%s', must be one of: %s", o.level, strings.Join(logLevels, ", "))
+	}
+	return nil
+}
+
+// jsonLogsWriter renders the JSON log entries written to it in a human-readable format when the `--json-logs` flag is set,
+// or writes the content unchanged otherwise
+type jsonLogsWriter struct {
+	options *jsonLogsOptions
+	out     io.Writer
+	// buf contains the beginning of a line that was not fully written yet
+	buf []byte
+}
+
+func (w *jsonLogsWriter) Write(p []byte) (int, error) {
+	if !w.options.enabled {
+		return w.out.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.buf[:i]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line, in case it was not terminated by a line feed
+func (w *jsonLogsWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	defer func() {
+		w.buf = nil
+	}()
+	return w.writeLine(w.buf)
+}
+
+func (w *jsonLogsWriter) writeLine(line []byte) error {
+	entry := map[string]interface{}{}
+	if err := json.Unmarshal(line, &entry); err != nil || entry == nil {
+		// not a JSON object, so let's print it unchanged
+		_, err := fmt.Fprintf(w.out, "%s\n", line)
+		return err
+	}
+	level := stringValue(popValue(entry, "level"))
+	msg := stringValue(popValue(entry, "msg", "message"))
+	ts := formatTimestamp(popValue(entry, "ts", "time"))
+
+	if w.options.level != "" {
+		if rank := logLevelRank(level); rank >= 0 && rank < logLevelRank(w.options.level) {
+			return nil
+		}
+	}
+	if w.options.grep != "" && !strings.Contains(msg, w.options.grep) {
+		return nil
+	}
+
+	keys := make([]string, 0, len(entry))
+	for key := range entry {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rendered := fmt.Sprintf("%-5s %s %s", strings.ToUpper(level), ts, msg)
+	for _, key := range keys {
+		rendered += fmt.Sprintf(" %s=%s", key, formatValue(entry[key]))
+	}
+	_, err := fmt.Fprintln(w.out, rendered)
+	return err
+}
+
+// popValue removes and returns the value of the first of the given keys found in the entry
+func popValue(entry map[string]interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		if value, found := entry[key]; found {
+			delete(entry, key)
+			return value
+		}
+	}
+	return nil
+}
+
+func stringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// formatTimestamp formats the timestamp which can be either a string or a number of seconds since the epoch
+func formatTimestamp(value interface{}) string {
+	if seconds, ok := value.(float64); ok {
+		sec := int64(seconds)
+		return time.Unix(sec, int64((seconds-float64(sec))*1e9)).UTC().Format(time.RFC3339Nano)
+	}
+	return stringValue(value)
+}
+
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		if strings.ContainsAny(s, " \t\"=") {
+			return fmt.Sprintf("%q", s)
+		}
+		return s
+	}
+	result, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(result)
 }
@@ -1,15 +1,18 @@
 package cmd_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/kubesaw/ksctl/pkg/cmd"
 	"github.com/kubesaw/ksctl/pkg/configuration"
 	. "github.com/kubesaw/ksctl/pkg/test"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -75,6 +78,245 @@ func TestLogs(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("logs of the operator pods by default", func(t *testing.T) {
+		// given
+		logsCmd := cmd.NewLogsCmd()
+		out := bytes.NewBuffer(nil)
+		logsCmd.SetOut(out)
+		logsCmd.SetArgs([]string{
+			"--target-cluster=host",
+			"--insecure-skip-tls-verify=true",
+		})
+
+		// when
+		_, err := logsCmd.ExecuteC()
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "UserSignup provisioned")
+	})
+
+	t.Run("logs with default tail and since", func(t *testing.T) {
+		// given
+		logsCmd := cmd.NewLogsCmd()
+		logsCmd.SetOut(bytes.NewBuffer(nil))
+		logsCmd.SetArgs([]string{
+			"--target-cluster=host",
+			"--insecure-skip-tls-verify=true",
+			"cheesecake",
+		})
+
+		// when
+		_, err := logsCmd.ExecuteC()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "200", logsCmd.Flag("tail").Value.String())
+		assert.Equal(t, "1h0m0s", logsCmd.Flag("since").Value.String())
+	})
+
+	t.Run("logs with overridden tail and since", func(t *testing.T) {
+		// given
+		logsCmd := cmd.NewLogsCmd()
+		logsCmd.SetOut(bytes.NewBuffer(nil))
+		logsCmd.SetArgs([]string{
+			"--target-cluster=host",
+			"--insecure-skip-tls-verify=true",
+			"--tail=-1",
+			"--since-time=2024-05-30T12:00:00Z",
+			"cheesecake",
+		})
+
+		// when
+		_, err := logsCmd.ExecuteC()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "-1", logsCmd.Flag("tail").Value.String())
+		assert.Equal(t, "0s", logsCmd.Flag("since").Value.String())
+	})
+
+	t.Run("logs without json-logs flag are unchanged", func(t *testing.T) {
+		// given
+		logsCmd := cmd.NewLogsCmd()
+		out := bytes.NewBuffer(nil)
+		logsCmd.SetOut(out)
+		logsCmd.SetArgs([]string{
+			"--target-cluster=host",
+			"--insecure-skip-tls-verify=true",
+			"cheesecake",
+		})
+
+		// when
+		_, err := logsCmd.ExecuteC()
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), logsContent)
+	})
+
+	t.Run("logs with json-logs flag", func(t *testing.T) {
+		// do not print the config details, so the output can be fully verified
+		configuration.Verbose = false
+		t.Cleanup(func() {
+			configuration.Verbose = true
+		})
+
+		for name, tc := range map[string]struct {
+			args     []string
+			expected string
+		}{
+			"all entries": {
+				expected: `INFO  2024-05-30T12:00:00.5Z reconciling UserSignup logger=controllers.usersignup name=john
+DEBUG 2024-05-30T12:00:01Z UserSignup details spec={"username":"john"}
+this is not a JSON line
+ERROR 2024-05-30T12:00:02Z unable to provision user error="the user is banned"
+INFO  2024-05-30T12:00:03Z UserSignup provisioned attempts=2 name=john
+`,
+			},
+			"with level": {
+				args: []string{"--level=info"},
+				expected: `INFO  2024-05-30T12:00:00.5Z reconciling UserSignup logger=controllers.usersignup name=john
+this is not a JSON line
+ERROR 2024-05-30T12:00:02Z unable to provision user error="the user is banned"
+INFO  2024-05-30T12:00:03Z UserSignup provisioned attempts=2 name=john
+`,
+			},
+			"with grep": {
+				args: []string{"--grep=UserSignup"},
+				expected: `INFO  2024-05-30T12:00:00.5Z reconciling UserSignup logger=controllers.usersignup name=john
+DEBUG 2024-05-30T12:00:01Z UserSignup details spec={"username":"john"}
+this is not a JSON line
+INFO  2024-05-30T12:00:03Z UserSignup provisioned attempts=2 name=john
+`,
+			},
+			"with level and grep": {
+				args: []string{"--level=error", "--grep=provision"},
+				expected: `this is not a JSON line
+ERROR 2024-05-30T12:00:02Z unable to provision user error="the user is banned"
+`,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				// given
+				logsCmd := cmd.NewLogsCmd()
+				out := bytes.NewBuffer(nil)
+				logsCmd.SetOut(out)
+				logsCmd.SetArgs(append([]string{
+					"--target-cluster=host",
+					"--insecure-skip-tls-verify=true",
+					"--json-logs",
+					"cheesecake",
+				}, tc.args...))
+
+				// when
+				_, err := logsCmd.ExecuteC()
+
+				// then
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, out.String())
+			})
+		}
+	})
+
+	t.Run("invalid json-logs flags", func(t *testing.T) {
+		for expectedErr, args := range map[string][]string{
+			"the '--grep' and '--level' flags can only be used along with the '--json-logs' flag":         {"--grep=UserSignup"},
+			"invalid log level 'verbose', must be one of: debug, info, warn, error, dpanic, panic, fatal": {"--json-logs", "--level=verbose"},
+		} {
+			// given
+			logsCmd := cmd.NewLogsCmd()
+			logsCmd.SetOut(bytes.NewBuffer(nil))
+			logsCmd.SetArgs(append([]string{
+				"--target-cluster=host",
+				"--insecure-skip-tls-verify=true",
+				"cheesecake",
+			}, args...))
+
+			// when
+			_, err := logsCmd.ExecuteC()
+
+			// then
+			require.EqualError(t, err, expectedErr)
+		}
+	})
+
+	t.Run("logs in all namespaces", func(t *testing.T) {
+		configuration.Verbose = false
+		t.Cleanup(func() {
+			configuration.Verbose = true
+		})
+
+		t.Run("with prefix", func(t *testing.T) {
+			// given
+			logsCmd := cmd.NewLogsCmd()
+			out := bytes.NewBuffer(nil)
+			logsCmd.SetOut(out)
+			logsCmd.SetArgs([]string{
+				"--target-cluster=host",
+				"--insecure-skip-tls-verify=true",
+				"--all-namespaces",
+			})
+
+			// when
+			_, err := logsCmd.ExecuteC()
+
+			// then
+			require.NoError(t, err)
+			output := out.String()
+			assert.True(t, strings.HasPrefix(output, "[toolchain-host-operator/cheesecake/default] {\"level\":\"info\""), output)
+			assert.Contains(t, output, "[toolchain-host-operator/cheesecake/default] this is not a JSON line\n")
+			assert.True(t, strings.HasSuffix(output, "[toolchain-member-operator/member-webhooks/webhook] webhook started\n"+
+				"[toolchain-member-operator/member-webhooks/webhook] webhook ready\n"), output)
+		})
+
+		t.Run("with json-logs flag", func(t *testing.T) {
+			// given
+			logsCmd := cmd.NewLogsCmd()
+			out := bytes.NewBuffer(nil)
+			logsCmd.SetOut(out)
+			logsCmd.SetArgs([]string{
+				"--target-cluster=host",
+				"--insecure-skip-tls-verify=true",
+				"-A",
+				"--json-logs",
+				"--level=error",
+			})
+
+			// when
+			_, err := logsCmd.ExecuteC()
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, `[toolchain-host-operator/cheesecake/default] this is not a JSON line
+[toolchain-host-operator/cheesecake/default] ERROR 2024-05-30T12:00:02Z unable to provision user error="the user is banned"
+[toolchain-member-operator/member-webhooks/webhook] webhook started
+[toolchain-member-operator/member-webhooks/webhook] webhook ready
+`, out.String())
+		})
+
+		t.Run("invalid flags", func(t *testing.T) {
+			for expectedErr, args := range map[string][]string{
+				"a pod name cannot be given along with the '--all-namespaces' flag, use a selector instead": {"--all-namespaces", "cheesecake"},
+				"the '--follow' flag cannot be used along with the '--all-namespaces' flag":                 {"--all-namespaces", "--follow"},
+			} {
+				// given
+				logsCmd := cmd.NewLogsCmd()
+				logsCmd.SetOut(bytes.NewBuffer(nil))
+				logsCmd.SetArgs(append([]string{
+					"--target-cluster=host",
+					"--insecure-skip-tls-verify=true",
+				}, args...))
+
+				// when
+				_, err := logsCmd.ExecuteC()
+
+				// then
+				require.EqualError(t, err, expectedErr)
+			}
+		})
+	})
+
 	t.Run("missing '--cluster' flag", func(t *testing.T) {
 		// given
 		logsCmd := cmd.NewLogsCmd()
@@ -93,6 +335,12 @@ func TestLogs(t *testing.T) {
 	})
 }
 
+const logsContent = `{"level":"info","ts":1717070400.5,"logger":"controllers.usersignup","msg":"reconciling UserSignup","name":"john"}
+{"level":"debug","ts":"2024-05-30T12:00:01Z","msg":"UserSignup details","spec":{"username":"john"}}
+this is not a JSON line
+{"level":"error","ts":"2024-05-30T12:00:02Z","msg":"unable to provision user","error":"the user is banned"}
+{"level":"info","ts":"2024-05-30T12:00:03Z","msg":"UserSignup provisioned","name":"john","attempts":2}`
+
 // NewLogsServer returns a new HTTP Server which supports:
 // - calls to `/api`
 // - calls to `/apis`
@@ -129,29 +377,47 @@ func NewLogsServer(t *testing.T) *httptest.Server {
 					Groups: []metav1.APIGroup{},
 				}
 
-			case "/api/v1/namespaces/toolchain-host-operator/pods/cheesecake":
-				response = corev1.Pod{
+			case "/api/v1/namespaces/toolchain-host-operator/pods":
+				if selector := req.URL.Query().Get("labelSelector"); selector != "provider=codeready-toolchain" {
+					t.Errorf("unexpected label selector: %s\n", selector)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				response = corev1.PodList{
 					TypeMeta: metav1.TypeMeta{
 						APIVersion: "v1",
-						Kind:       "Pod",
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "toolchain-host-operator",
-						Name:      "cheesecake",
-					},
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name: "default",
-							},
-						},
+						Kind:       "PodList",
 					},
-					Status: corev1.PodStatus{
-						Phase: "Running",
+					Items: []corev1.Pod{newCheesecakePod()},
+				}
+			case "/api/v1/pods":
+				if selector := req.URL.Query().Get("labelSelector"); selector != "provider=codeready-toolchain" {
+					t.Errorf("unexpected label selector: %s\n", selector)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				response = corev1.PodList{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "v1",
+						Kind:       "PodList",
 					},
+					Items: []corev1.Pod{newWebhookPod(), newCheesecakePod()},
+				}
+			case "/api/v1/namespaces/toolchain-member-operator/pods/member-webhooks/log":
+				if tail := req.URL.Query().Get("tailLines"); tail != "200" {
+					t.Errorf("unexpected tail lines: %s\n", tail)
 				}
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("webhook started\nwebhook ready\n")) // nolint: errcheck
+				return
+			case "/api/v1/namespaces/toolchain-host-operator/pods/cheesecake":
+				response = newCheesecakePod()
 			case "/api/v1/namespaces/toolchain-host-operator/pods/cheesecake/log":
-				response = "some content"
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(logsContent)) // nolint: errcheck
+				return
 			default:
 				t.Errorf("not found: %s %s\n", req.Method, req.URL)
 				w.WriteHeader(http.StatusNotFound)
@@ -172,3 +438,37 @@ func NewLogsServer(t *testing.T) *httptest.Server {
 		w.Write(output) // nolint: errcheck
 	}))
 }
+
+func newWebhookPod() corev1.Pod {
+	pod := newCheesecakePod()
+	pod.Namespace = "toolchain-member-operator"
+	pod.Name = "member-webhooks"
+	pod.Spec.Containers[0].Name = "webhook"
+	return pod
+}
+
+func newCheesecakePod() corev1.Pod {
+	return corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "toolchain-host-operator",
+			Name:      "cheesecake",
+			Labels: map[string]string{
+				"provider": "codeready-toolchain",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "default",
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: "Running",
+		},
+	}
+}
@@ -16,10 +16,11 @@ func NewPromoteSpaceCmd() *cobra.Command {
 		Short: "Promote a Space to the given tier",
 		Long: `Promote a Space to the given tier. There are two expected 
 parameters - first one is Space name and second is the name of the target NSTemplateTier that the space should be promoted to`,
-		Args: cobra.ExactArgs(2),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeSpaceName,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 			return PromoteSpace(ctx, args[0], args[1])
 		},
 	}
@@ -27,6 +28,10 @@ parameters - first one is Space name and second is the name of the target NSTemp
 
 func PromoteSpace(ctx *clicontext.CommandContext, spaceName, targetTier string) error {
 	return client.PatchSpace(ctx, spaceName, func(space *toolchainv1alpha1.Space) (bool, error) {
+		if space.Spec.TierName == targetTier {
+			ioutils.PrintNothingToDo(ctx, "The Space '%s' is already in the '%s' tier", spaceName, targetTier)
+			return false, nil
+		}
 
 		cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
 		if err != nil {
@@ -38,7 +43,7 @@ func PromoteSpace(ctx *clicontext.CommandContext, spaceName, targetTier string)
 		}
 
 		// verify the NSTemplateTier exists
-		if _, err := client.GetNSTemplateTier(cfg, cl, targetTier); err != nil {
+		if _, err := client.GetNSTemplateTier(ctx, cfg, cl, targetTier); err != nil {
 			return false, err
 		}
 
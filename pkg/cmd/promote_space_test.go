@@ -32,6 +32,7 @@ func TestPromoteSpaceCmdWhenAnswerIsY(t *testing.T) {
 	assertSpaceSpec(t, fakeClient, space)
 	output := term.Output()
 	assert.Contains(t, output, "promote the Space 'testspace' to the 'advanced' tier?")
+	assert.Contains(t, output, ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 	assert.Contains(t, output, "Successfully promoted Space")
 	assert.NotContains(t, output, "cool-token")
 }
@@ -96,6 +97,27 @@ func TestPromoteSpaceCmdWhenNSTemplateTierNotFound(t *testing.T) {
 	assert.NotContains(t, output, "cool-token")
 }
 
+func TestPromoteSpaceCmdWhenAlreadyInTargetTier(t *testing.T) {
+	// given
+	space := newSpace()
+	newClient, fakeClient := NewFakeClients(t, space, newNSTemplateTier("base"))
+	SetFileConfig(t, Host())
+	term := NewFakeTerminalWithResponse("Y")
+	ctx := clicontext.NewCommandContext(term, newClient)
+
+	// when
+	err := cmd.PromoteSpace(ctx, space.Name, "base")
+
+	// then
+	require.NoError(t, err)
+	assertSpaceSpec(t, fakeClient, space) // space should be unchanged
+	output := term.Output()
+	assert.Contains(t, output, "The Space 'testspace' is already in the 'base' tier: already in desired state; nothing to do")
+	assert.NotContains(t, output, "promote the Space 'testspace' to the 'base' tier?")
+	assert.NotContains(t, output, "Successfully promoted Space")
+	assert.NotContains(t, output, "cool-token")
+}
+
 func newNSTemplateTier(name string) *toolchainv1alpha1.NSTemplateTier {
 	nsTemplateTier := &toolchainv1alpha1.NSTemplateTier{
 		ObjectMeta: metav1.ObjectMeta{
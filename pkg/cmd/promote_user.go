@@ -19,7 +19,7 @@ parameters - first one is MasterUserRecord name and second is the name of the ta
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 			return PromoteUser(ctx, args[0], args[1])
 		},
 	}
@@ -27,6 +27,10 @@ parameters - first one is MasterUserRecord name and second is the name of the ta
 
 func PromoteUser(ctx *clicontext.CommandContext, murName, targetTier string) error {
 	return client.PatchMasterUserRecord(ctx, murName, func(mur *toolchainv1alpha1.MasterUserRecord) (bool, error) {
+		if mur.Spec.TierName == targetTier {
+			ioutils.PrintNothingToDo(ctx, "The MasterUserRecord '%s' is already in the '%s' user tier", murName, targetTier)
+			return false, nil
+		}
 
 		cfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
 		if err != nil {
@@ -38,7 +42,7 @@ func PromoteUser(ctx *clicontext.CommandContext, murName, targetTier string) err
 		}
 
 		// verify user tier exists
-		if _, err := client.GetUserTier(cfg, cl, targetTier); err != nil {
+		if _, err := client.GetUserTier(ctx, cfg, cl, targetTier); err != nil {
 			return false, err
 		}
 
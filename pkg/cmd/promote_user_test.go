@@ -33,6 +33,7 @@ func TestPromoteUserCmdWhenAnswerIsY(t *testing.T) {
 	assertMasterUserRecordSpec(t, fakeClient, mur)
 	output := term.Output()
 	assert.Contains(t, output, "promote the MasterUserRecord 'testmur' to the 'deactivate180' user tier?")
+	assert.Contains(t, output, ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 	assert.Contains(t, output, "Successfully promoted MasterUserRecord")
 	assert.NotContains(t, output, "cool-token")
 }
@@ -57,6 +58,26 @@ func TestPromoteUserCmdWhenAnswerIsN(t *testing.T) {
 	assert.NotContains(t, output, "cool-token")
 }
 
+func TestPromoteUserCmdWhenAlreadyInTargetTier(t *testing.T) {
+	// given
+	mur := masteruserrecord.NewMasterUserRecord(t, "testmur", masteruserrecord.TierName("deactivate180"))
+	newClient, fakeClient := NewFakeClients(t, mur, newUserTier("deactivate180"))
+	SetFileConfig(t, Host())
+	term := NewFakeTerminalWithResponse("Y")
+	ctx := clicontext.NewCommandContext(term, newClient)
+
+	// when
+	err := cmd.PromoteUser(ctx, mur.Name, "deactivate180")
+
+	// then
+	require.NoError(t, err)
+	assertMasterUserRecordSpec(t, fakeClient, mur) // mur should be unchanged
+	output := term.Output()
+	assert.Contains(t, output, "The MasterUserRecord 'testmur' is already in the 'deactivate180' user tier: already in desired state; nothing to do")
+	assert.NotContains(t, output, "promote the MasterUserRecord 'testmur' to the 'deactivate180' user tier?")
+	assert.NotContains(t, output, "Successfully promoted MasterUserRecord")
+}
+
 func TestPromoteUserCmdWhenMasterUserRecordNotFound(t *testing.T) {
 	// given
 	mur := masteruserrecord.NewMasterUserRecord(t, "testmur", masteruserrecord.TierName("deactivate30"))
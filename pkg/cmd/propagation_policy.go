@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/kubesaw/ksctl/pkg/cmd/flags"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var propagationPolicies = []string{"foreground", "background", "orphan"}
+
+// addPropagationPolicyFlag adds the `--propagation-policy` flag (with the completion of its values) to the given command
+func addPropagationPolicyFlag(command *cobra.Command, policy *string, defaultPolicy string) {
+	command.Flags().StringVar(policy, "propagation-policy", defaultPolicy, "Whether and how the dependent resources are deleted. One of: "+strings.Join(propagationPolicies, ", "))
+	flags.MustRegisterCompletionFunc(command, "propagation-policy", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return propagationPolicies, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// deletionPropagation returns the deletion propagation matching the given value of the `--propagation-policy` flag
+func deletionPropagation(policy string) (metav1.DeletionPropagation, error) {
+	switch strings.ToLower(policy) {
+	case "foreground":
+		return metav1.DeletePropagationForeground, nil
+	case "background":
+		return metav1.DeletePropagationBackground, nil
+	case "orphan":
+		return metav1.DeletePropagationOrphan, nil
+	}
+	return "", ioutils.Unsupportedf("unsupported propagation policy '%s', the supported ones are: %s", policy, strings.Join(propagationPolicies, ", "))
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
@@ -25,13 +24,14 @@ one or more users specified by their MasterUserRecord name.`,
 		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 
 			return RemoveSpaceUsers(ctx, spaceName, users)
 		},
 	}
 	command.Flags().StringVarP(&spaceName, "space", "s", "", "the name of the space to remove users from")
 	flags.MustMarkRequired(command, "space")
+	flags.MustRegisterCompletionFunc(command, "space", completeSpaceFlag)
 	command.Flags().StringArrayVarP(&users, "users", "u", []string{}, "the masteruserrecord names of the users to remove from the space")
 	flags.MustMarkRequired(command, "users")
 
@@ -43,6 +43,7 @@ func RemoveSpaceUsers(ctx *clicontext.CommandContext, spaceName string, usersToR
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(cfg, cfg.OperatorNamespace)
 	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
 	if err != nil {
 		return err
@@ -50,7 +51,7 @@ func RemoveSpaceUsers(ctx *clicontext.CommandContext, spaceName string, usersToR
 
 	// get Space
 	ctx.Println("Checking space...")
-	space, err := client.GetSpace(cl, cfg.OperatorNamespace, spaceName)
+	space, err := client.GetSpace(ctx, cl, cfg.OperatorNamespace, spaceName)
 	if err != nil {
 		return err
 	}
@@ -58,7 +59,7 @@ func RemoveSpaceUsers(ctx *clicontext.CommandContext, spaceName string, usersToR
 	// get SpaceBindings to delete
 	spaceBindingsToDelete := []*toolchainv1alpha1.SpaceBinding{}
 	for _, murName := range usersToRemove {
-		sbs, err := client.ListSpaceBindings(cl, cfg.OperatorNamespace, client.ForSpace(spaceName), client.ForMasterUserRecord(murName))
+		sbs, err := client.ListSpaceBindings(ctx, cl, cfg.OperatorNamespace, client.ForSpace(spaceName), client.ForMasterUserRecord(murName))
 		if err != nil {
 			return err
 		}
@@ -83,7 +84,7 @@ func RemoveSpaceUsers(ctx *clicontext.CommandContext, spaceName string, usersToR
 	ctx.Println("Deleting SpaceBinding(s)...")
 	// delete SpaceBindings
 	for _, sb := range spaceBindingsToDelete {
-		if err := cl.Delete(context.TODO(), sb); err != nil {
+		if err := cl.Delete(ctx, sb); err != nil {
 			return err
 		}
 	}
@@ -42,6 +42,7 @@ func TestRemoveSpaceUsers(t *testing.T) {
 			output := term.Output()
 			assertSpaceBindingsRemaining(t, fakeClient, []string{}) // should be deleted
 			assert.Contains(t, output, "Are you sure that you want to remove users from the above Space?")
+			assert.Contains(t, output, ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 			assert.Contains(t, output, "SpaceBinding(s) successfully deleted")
 			assert.NotContains(t, output, "cool-token")
 		})
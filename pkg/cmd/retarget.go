@@ -21,7 +21,7 @@ func NewRetargetCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 			return Retarget(ctx, args[0], args[1])
 		},
 	}
@@ -32,6 +32,7 @@ func Retarget(ctx *clicontext.CommandContext, spaceName, targetCluster string) e
 	if err != nil {
 		return err
 	}
+	ctx.SetTarget(hostClusterConfig, hostClusterConfig.OperatorNamespace)
 	hostClusterClient, err := ctx.NewClient(hostClusterConfig.Token, hostClusterConfig.ServerAPI)
 	if err != nil {
 		return err
@@ -43,7 +44,7 @@ func Retarget(ctx *clicontext.CommandContext, spaceName, targetCluster string) e
 		return err
 	}
 
-	space, err := client.GetSpace(hostClusterClient, hostClusterConfig.OperatorNamespace, spaceName)
+	space, err := client.GetSpace(ctx, hostClusterClient, hostClusterConfig.OperatorNamespace, spaceName)
 	if err != nil {
 		return err
 	}
@@ -51,15 +52,16 @@ func Retarget(ctx *clicontext.CommandContext, spaceName, targetCluster string) e
 	// let's get the creator
 	creator := space.Labels[toolchainv1alpha1.SpaceCreatorLabelKey]
 	if creator == "" {
-		return fmt.Errorf("spaces without the creator label are not supported")
+		return ioutils.Unsupportedf("spaces without the creator label are not supported")
 	}
-	userSignup, err := client.GetUserSignup(hostClusterClient, hostClusterConfig.OperatorNamespace, creator)
+	userSignup, err := client.GetUserSignup(ctx, hostClusterClient, hostClusterConfig.OperatorNamespace, creator)
 	if err != nil {
 		return err
 	}
 
 	if space.Spec.TargetCluster == memberToolchainClusterName(memberClusterConfig) {
-		return fmt.Errorf("the Space '%s' is already targeted to cluster '%s'", spaceName, targetCluster)
+		ioutils.PrintNothingToDo(ctx, "The Space '%s' is already targeted to cluster '%s'", spaceName, targetCluster)
+		return nil
 	}
 
 	// target cluster must have 'member' cluster type
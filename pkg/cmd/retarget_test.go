@@ -37,6 +37,7 @@ func TestRetarget(t *testing.T) {
 		assert.Contains(t, term.Output(), "!!!  DANGER ZONE  !!!")
 		assert.Contains(t, term.Output(), fmt.Sprintf("Are you sure that you want to retarget the Space '%s' owned (created) by UserSignup '%s' to cluster 'member2'?", space.Name, userSignup.Name))
 		assert.Contains(t, term.Output(), "Space to be retargeted")
+		assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
 		assert.Contains(t, term.Output(), fmt.Sprintf("Owned (created) by UserSignup '%s' with spec", userSignup.Name))
 		assert.Contains(t, term.Output(), "Space has been patched to target cluster member2")
 		assert.Contains(t, term.Output(), "Space has been retargeted to cluster member2")
@@ -61,14 +62,17 @@ func TestRetarget(t *testing.T) {
 			// given
 			term := NewFakeTerminalWithResponse("y")
 			space := testspace.NewSpace(test.HostOperatorNs, "john-dev", testspace.WithCreatorLabel("john"), testspace.WithSpecTargetCluster("member-m2.devcluster.openshift.com"))
-			newClient, _ := prepareRetargetSpace(t, space, userSignup)
+			newClient, fakeClient := prepareRetargetSpace(t, space, userSignup)
 			ctx := clicontext.NewCommandContext(term, newClient)
 
 			// when
 			err := cmd.Retarget(ctx, space.Name, "member2")
 
 			// then
-			require.EqualError(t, err, fmt.Sprintf(`the Space '%s' is already targeted to cluster '%s'`, space.Name, "member2"))
+			require.NoError(t, err)
+			testspace.AssertThatSpace(t, test.HostOperatorNs, space.Name, fakeClient).HasSpecTargetCluster("member-m2.devcluster.openshift.com")
+			assert.Contains(t, term.Output(), "The Space 'john-dev' is already targeted to cluster 'member2': already in desired state; nothing to do")
+			assert.NotContains(t, term.Output(), "Are you sure")
 		})
 
 		t.Run("failed to get member cluster config", func(t *testing.T) {
@@ -1,59 +1,162 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"runtime/debug"
 
+	"github.com/kubesaw/ksctl/pkg/client"
 	"github.com/kubesaw/ksctl/pkg/cmd/adm"
 	"github.com/kubesaw/ksctl/pkg/cmd/generate"
 	"github.com/kubesaw/ksctl/pkg/configuration"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
 	"github.com/kubesaw/ksctl/pkg/version"
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = NewRootCmd()
 
+// exit codes returned by the Run func
+const (
+	// ExitCodeError any error that is not covered by a more specific exit code
+	ExitCodeError = 1
+	// ExitCodeNotFound a resource required by the command was not found
+	ExitCodeNotFound = 2
+	// ExitCodeUnsupported the requested feature, option or resource is not supported
+	ExitCodeUnsupported = 3
+	// ExitCodeConfirmationDeclined the user declined the confirmation, so the command did nothing
+	ExitCodeConfirmationDeclined = 4
+	// ExitCodeNoClustersConfig there is no cluster configured in the ksctl config file
+	ExitCodeNoClustersConfig = 5
+	// ExitCodePanic the command panicked
+	ExitCodePanic = 6
+)
+
+// redactConfigOnError whether the secrets of the loaded configuration should be removed from the error messages
+var redactConfigOnError = true
+
 func NewRootCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:     "ksctl",
 		Short:   "KubeSaw command-line",
 		Long:    `KubeSaw command-line tool that helps you to manage your KubeSaw service`,
 		Version: version.NewMessage(),
+		// errors are printed by the Run func, so the secrets can be redacted first
+		SilenceErrors: true,
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			// the args and flags were successfully parsed at this point, so the usage is not
+			// relevant for the errors returned by the command itself
+			cmd.SilenceUsage = true
+		},
 	}
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	// interrupting ksctl (eg, with Ctrl-C) cancels the context of the command, and thus the in-flight API calls
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	rootCmd.SetContext(ctx)
+	exitCode := Run(rootCmd, os.Stdout)
+	stop()
+	os.Exit(exitCode)
+}
+
+// Run executes the given command and returns the exit code (see the ExitCode... constants).
+// The message of any returned error or recovered panic is printed in the given output,
+// after the secrets of the loaded configuration have been redacted (unless disabled via the `--redact-config-on-error` flag)
+func Run(command *cobra.Command, out io.Writer) (exitCode int) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintln(out, redact(fmt.Sprintf("panic: %v\n\n%s", r, debug.Stack())))
+			exitCode = ExitCodePanic
+		}
+	}()
+	ioutils.ResetConfirmationDeclined()
+	if err := command.Execute(); err != nil {
+		noClustersErr := configuration.NoClustersConfiguredError{}
+		if errors.As(err, &noClustersErr) {
+			fmt.Fprintf(out, `Welcome to ksctl!
+
+There is no cluster configured in '%s' yet.
+To get started, ask your KubeSaw administrator for your ksctl.yaml file (generated with 'ksctl generate cli-configs')
+and save it as ~/.ksctl.yaml, or point to it using the '--config' flag.
+`, noClustersErr.Path)
+			return ExitCodeNoClustersConfig
+		}
+		fmt.Fprintln(out, redact(err.Error()))
+		return exitCodeOf(err)
+	}
+	if ioutils.ConfirmationDeclined() {
+		return ExitCodeConfirmationDeclined
+	}
+	return 0
+}
+
+// exitCodeOf returns the exit code matching the kind of the given error
+func exitCodeOf(err error) int {
+	if apierrors.IsNotFound(err) {
+		return ExitCodeNotFound
+	}
+	if errors.As(err, &ioutils.UnsupportedError{}) {
+		return ExitCodeUnsupported
+	}
+	return ExitCodeError
+}
+
+func redact(msg string) string {
+	if !redactConfigOnError {
+		return msg
 	}
+	return ioutils.RedactSecrets(msg)
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&configuration.ConfigFileFlag, "config", "", "config file (default is $HOME/.ksctl.yaml)")
+	rootCmd.PersistentFlags().StringVar(&configuration.KubeconfigFlag, "kubeconfig", "", "kubeconfig file to use instead of the config file, where the name of each context is used as the cluster name")
 	rootCmd.PersistentFlags().BoolVarP(&configuration.Verbose, "verbose", "v", false, "print extra info/debug messages")
+	rootCmd.PersistentFlags().BoolVarP(&configuration.Quiet, "quiet", "q", false, "do not print the banner showing the cluster targeted by the mutating commands")
+	rootCmd.PersistentFlags().StringVar(&configuration.Impersonate, "impersonate", "", "user or service account (as 'system:serviceaccount:<namespace>:<name>') to impersonate in the requests to the clusters")
+	rootCmd.PersistentFlags().BoolVarP(&ioutils.AssumeYes, "assume-yes", "y", false, "Automatically answer yes for all questions.")
+	rootCmd.PersistentFlags().BoolVar(&ioutils.AssumeYes, "yes", false, "Alias of '--assume-yes'")
+	rootCmd.PersistentFlags().StringVar(&ioutils.ConfirmationPrefix, "confirmation-prefix", "", "prefix of the lines printed when asking for a confirmation (eg, '[confirm] '), so the confirmations can be extracted from the logs")
+	rootCmd.PersistentFlags().IntVar(&client.NewClientRetries, "client-retries", client.NewClientRetries, "number of times the connection to a cluster is retried (with an exponential backoff) when the API server can't be reached")
+	rootCmd.PersistentFlags().BoolVar(&client.TraceRequests, "trace-requests", false, "log each request sent to the API servers (verb, resource, namespace, name, status and latency) in the standard error output")
+	rootCmd.PersistentFlags().Int64Var(&client.ListPageSize, "list-page-size", client.ListPageSize, "maximum number of resources returned by a single request when listing resources page by page")
+	rootCmd.PersistentFlags().BoolVar(&redactConfigOnError, "redact-config-on-error", true, "redact the tokens of the loaded config from error messages")
 
 	// commands with go runtime client
 	rootCmd.AddCommand(NewAddSpaceUsersCmd())
 	rootCmd.AddCommand(NewApproveCmd())
 	rootCmd.AddCommand(NewBanCmd())
 	rootCmd.AddCommand(NewDeactivateCmd())
+	rootCmd.AddCommand(NewCreateSpaceCmd())
+	rootCmd.AddCommand(NewDeleteSpaceCmd())
 	rootCmd.AddCommand(NewPromoteSpaceCmd())
 	rootCmd.AddCommand(NewPromoteUserCmd())
 	rootCmd.AddCommand(NewRemoveSpaceUsersCmd())
 	rootCmd.AddCommand(NewRetargetCmd())
 	rootCmd.AddCommand(NewStatusCmd())
+	rootCmd.AddCommand(NewListMemberStatusCmd())
+	rootCmd.AddCommand(NewGetIdentityCmd())
+	rootCmd.AddCommand(NewDescribeUserCmd())
+	rootCmd.AddCommand(NewClustersCmd())
 	rootCmd.AddCommand(NewGdprDeleteCmd())
 	rootCmd.AddCommand(NewCreateSocialEventCmd())
 	rootCmd.AddCommand(NewGetCmd())
 	rootCmd.AddCommand(NewLogsCmd())
 	rootCmd.AddCommand(NewDescribeCmd())
+	rootCmd.AddCommand(NewDeleteCmd())
 	rootCmd.AddCommand(NewDisableUserCmd())
+	rootCmd.AddCommand(NewVersionCmd())
 
 	// administrative commands
 	rootCmd.AddCommand(adm.NewAdmCmd())
@@ -0,0 +1,206 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRunRedactsConfig(t *testing.T) {
+	// given
+	SetFileConfig(t, Host())
+	newCommand := func(run func(configuration.ClusterConfig) error) *cobra.Command {
+		command := cmd.NewRootCmd()
+		command.SetArgs([]string{})
+		command.SetOut(bytes.NewBuffer(nil))
+		command.RunE = func(_ *cobra.Command, _ []string) error {
+			cfg, err := configuration.LoadClusterConfig(NewFakeTerminal(), configuration.HostName)
+			require.NoError(t, err)
+			return run(cfg)
+		}
+		return command
+	}
+
+	t.Run("when command panics", func(t *testing.T) {
+		// given
+		command := newCommand(func(cfg configuration.ClusterConfig) error {
+			panic(fmt.Sprintf("cannot connect with token '%s'", cfg.Token))
+		})
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, cmd.ExitCodePanic, exitCode)
+		assert.Contains(t, out.String(), "panic: cannot connect with token '*****'")
+		assert.NotContains(t, out.String(), "cool-token")
+	})
+
+	t.Run("when command fails", func(t *testing.T) {
+		// given
+		command := newCommand(func(cfg configuration.ClusterConfig) error {
+			return fmt.Errorf("unauthorized: Bearer %s", cfg.Token)
+		})
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, cmd.ExitCodeError, exitCode)
+		assert.Equal(t, "unauthorized: Bearer *****\n", out.String())
+	})
+
+	t.Run("when command succeeds", func(t *testing.T) {
+		// given
+		command := newCommand(func(cfg configuration.ClusterConfig) error {
+			return nil
+		})
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, 0, exitCode)
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestRunExitCodes(t *testing.T) {
+	// given
+	newCommand := func(run func() error) *cobra.Command {
+		command := cmd.NewRootCmd()
+		command.SetArgs([]string{})
+		command.SetOut(bytes.NewBuffer(nil))
+		command.RunE = func(_ *cobra.Command, _ []string) error {
+			return run()
+		}
+		return command
+	}
+
+	t.Run("when resource is not found", func(t *testing.T) {
+		// given
+		command := newCommand(func() error {
+			return fmt.Errorf("cannot ban: %w", apierrors.NewNotFound(schema.GroupResource{Resource: "usersignups"}, "john"))
+		})
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, cmd.ExitCodeNotFound, exitCode)
+		assert.Equal(t, "cannot ban: usersignups \"john\" not found\n", out.String())
+	})
+
+	t.Run("when output format is not supported", func(t *testing.T) {
+		// given
+		command := newCommand(func() error {
+			return ioutils.ValidateOutputFormat("yaml")
+		})
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, cmd.ExitCodeUnsupported, exitCode)
+		assert.Equal(t, "unsupported output format 'yaml', the only supported format is 'json'\n", out.String())
+	})
+
+	t.Run("when confirmation is declined", func(t *testing.T) {
+		// given
+		command := newCommand(func() error {
+			NewFakeTerminalWithResponse("n").AskForConfirmation(ioutils.WithMessagef("do it?"))
+			return nil
+		})
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, cmd.ExitCodeConfirmationDeclined, exitCode)
+		assert.Empty(t, out.String())
+
+		t.Run("declined confirmation is not remembered by the next run", func(t *testing.T) {
+			// when
+			exitCode := cmd.Run(newCommand(func() error { return nil }), out)
+
+			// then
+			assert.Equal(t, 0, exitCode)
+		})
+	})
+
+	t.Run("when confirmation is accepted", func(t *testing.T) {
+		// given
+		command := newCommand(func() error {
+			NewFakeTerminalWithResponse("y").AskForConfirmation(ioutils.WithMessagef("do it?"))
+			return nil
+		})
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, 0, exitCode)
+	})
+}
+
+func TestRunWithoutClustersConfigured(t *testing.T) {
+	// given
+	command := cmd.NewRootCmd()
+	command.AddCommand(cmd.NewStatusCmd())
+	command.SetArgs([]string{"status"})
+	commandOut := bytes.NewBuffer(nil)
+	command.SetOut(commandOut)
+	command.SetErr(commandOut)
+
+	t.Run("when config file does not exist", func(t *testing.T) {
+		// given
+		configuration.ConfigFileFlag = "/tmp/should-not-exist.yaml"
+		t.Cleanup(func() {
+			configuration.ConfigFileFlag = ""
+		})
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, cmd.ExitCodeNoClustersConfig, exitCode)
+		assert.Contains(t, out.String(), "There is no cluster configured in '/tmp/should-not-exist.yaml' yet.")
+		assert.Contains(t, out.String(), "save it as ~/.ksctl.yaml, or point to it using the '--config' flag")
+		assert.NotContains(t, out.String(), "Usage:")
+		assert.NotContains(t, commandOut.String(), "Usage:")
+	})
+
+	t.Run("when config file is empty", func(t *testing.T) {
+		// given
+		SetFileConfig(t)
+		out := bytes.NewBuffer(nil)
+
+		// when
+		exitCode := cmd.Run(command, out)
+
+		// then
+		assert.Equal(t, cmd.ExitCodeNoClustersConfig, exitCode)
+		assert.Contains(t, out.String(), fmt.Sprintf("There is no cluster configured in '%s' yet.", configuration.ConfigFileFlag))
+		assert.NotContains(t, out.String(), "Usage:")
+		assert.NotContains(t, commandOut.String(), "Usage:")
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/types"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func NewStatusCmd() *cobra.Command {
@@ -23,7 +24,7 @@ func NewStatusCmd() *cobra.Command {
 		Args:  cobra.MaximumNArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
-			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
 			return Status(ctx)
 		},
 	}
@@ -38,12 +39,8 @@ func Status(ctx *clicontext.CommandContext) error {
 	if err != nil {
 		return err
 	}
-	namespacedName := types.NamespacedName{
-		Namespace: cfg.OperatorNamespace,
-		Name:      "toolchain-status",
-	}
-	status := &toolchainv1alpha1.ToolchainStatus{}
-	if err := cl.Get(context.TODO(), namespacedName, status); err != nil {
+	status, err := getToolchainStatus(ctx, cl, cfg.OperatorNamespace)
+	if err != nil {
 		return err
 	}
 
@@ -59,3 +56,15 @@ func Status(ctx *clicontext.CommandContext) error {
 	}
 	return ctx.PrintObject(status, title)
 }
+
+func getToolchainStatus(ctx context.Context, cl runtimeclient.Client, namespace string) (*toolchainv1alpha1.ToolchainStatus, error) {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      "toolchain-status",
+	}
+	status := &toolchainv1alpha1.ToolchainStatus{}
+	if err := cl.Get(ctx, namespacedName, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
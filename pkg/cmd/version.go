@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/cmd/flags"
+	"github.com/kubesaw/ksctl/pkg/configuration"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+	"github.com/kubesaw/ksctl/pkg/version"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/spf13/cobra"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// copiedCSVLabel the label set by OLM on the copies of the CSVs of the operators installed in all namespaces
+const copiedCSVLabel = "olm.copiedFrom"
+
+func NewVersionCmd() *cobra.Command {
+	var targetCluster string
+	command := &cobra.Command{
+		Use:   "version [-t <cluster-name>]",
+		Short: "Print the version of ksctl and of the operator running in the given cluster",
+		Long: `Print the version of ksctl and, when a target cluster is given, the version of the operator(s)
+running in the operator namespace of that cluster (as declared in their ClusterServiceVersion).
+When a target cluster is given, a warning is also printed in the standard error if the version of the host operator
+(as reported in the ToolchainStatus of the host cluster) is not compatible with this version of ksctl`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			term := ioutils.NewTerminal(cmd.InOrStdin, cmd.OutOrStdout)
+			ctx := clicontext.NewCommandContext(term, client.DefaultNewClient).WithContext(cmd.Context())
+			return Version(ctx, targetCluster, cmd.ErrOrStderr())
+		},
+	}
+	command.Flags().StringVarP(&targetCluster, "target-cluster", "t", "", "The cluster running the operator to print the version of")
+	flags.MustRegisterCompletionFunc(command, "target-cluster", completeClusterFlag)
+	return command
+}
+
+// Version prints the version of ksctl and of the operator(s) running in the given cluster (if any).
+// The warning about an incompatible host operator is printed in the given errOut, so it does not mix with the versions
+func Version(ctx *clicontext.CommandContext, clusterName string, errOut io.Writer) error {
+	ctx.Printlnf("ksctl %s", version.NewMessage())
+	if clusterName == "" {
+		return nil
+	}
+
+	cfg, err := configuration.LoadClusterConfig(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	cl, err := ctx.NewClient(cfg.Token, cfg.ServerAPI)
+	if err != nil {
+		return err
+	}
+	csvs, err := getOperatorCSVs(ctx, cl, cfg.OperatorNamespace)
+	if err != nil {
+		return err
+	}
+	if len(csvs) == 0 {
+		ctx.Printlnf("No ClusterServiceVersion found in the '%s' namespace of the '%s' cluster", cfg.OperatorNamespace, clusterName)
+	}
+	for _, csv := range csvs {
+		ctx.Printlnf("%s: version '%s', phase '%s'", csv.Name, csv.Spec.Version.String(), csv.Status.Phase)
+	}
+	if err := checkHostOperatorVersion(ctx, cfg, cl); err != nil {
+		fmt.Fprintf(errOut, "WARNING: %s\n", err.Error())
+	}
+	return nil
+}
+
+// checkHostOperatorVersion returns an error if the version of the host operator reported in the ToolchainStatus of the host cluster
+// is not compatible with this version of ksctl, or if it can't be checked. The given client is used if the given cluster is the host one
+func checkHostOperatorVersion(ctx *clicontext.CommandContext, cfg configuration.ClusterConfig, cl runtimeclient.Client) error {
+	if cfg.ClusterType != configuration.Host {
+		hostCfg, err := configuration.LoadClusterConfig(ctx, configuration.HostName)
+		if err != nil {
+			return fmt.Errorf("the compatibility with the host operator could not be checked: %w", err)
+		}
+		if cl, err = ctx.NewClient(hostCfg.Token, hostCfg.ServerAPI); err != nil {
+			return fmt.Errorf("the compatibility with the host operator could not be checked: %w", err)
+		}
+		cfg = hostCfg
+	}
+	status, err := getToolchainStatus(ctx, cl, cfg.OperatorNamespace)
+	if err != nil {
+		return fmt.Errorf("the compatibility with the host operator could not be checked: %w", err)
+	}
+	if status.Status.HostOperator == nil {
+		return fmt.Errorf("the compatibility with the host operator could not be checked: its version is not reported in the ToolchainStatus")
+	}
+	return version.CheckHostOperatorVersion(status.Status.HostOperator.Version)
+}
+
+// getOperatorCSVs returns the ClusterServiceVersions of the operators installed in the given namespace,
+// ignoring the copies made by OLM for the operators installed in all namespaces
+func getOperatorCSVs(ctx context.Context, cl runtimeclient.Client, namespace string) ([]olmv1alpha1.ClusterServiceVersion, error) {
+	csvs := &olmv1alpha1.ClusterServiceVersionList{}
+	if err := cl.List(ctx, csvs, runtimeclient.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	operatorCSVs := make([]olmv1alpha1.ClusterServiceVersion, 0, len(csvs.Items))
+	for _, csv := range csvs.Items {
+		if _, copied := csv.Labels[copiedCSVLabel]; copied {
+			continue
+		}
+		operatorCSVs = append(operatorCSVs, csv)
+	}
+	sort.Slice(operatorCSVs, func(i, j int) bool {
+		return operatorCSVs[i].Name < operatorCSVs[j].Name
+	})
+	return operatorCSVs, nil
+}
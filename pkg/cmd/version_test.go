@@ -0,0 +1,135 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	"github.com/codeready-toolchain/toolchain-common/pkg/test"
+	"github.com/kubesaw/ksctl/pkg/client"
+	"github.com/kubesaw/ksctl/pkg/cmd"
+	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	. "github.com/kubesaw/ksctl/pkg/test"
+	"github.com/kubesaw/ksctl/pkg/version"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVersion(t *testing.T) {
+	// given
+	require.NoError(t, client.AddToScheme())
+	SetFileConfig(t, Host(), Member())
+
+	t.Run("without target cluster", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.Version(ctx, "", &bytes.Buffer{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "ksctl commit: 'unknown', build time: 'unknown'\n", term.Output())
+	})
+
+	t.Run("with operator version of the target cluster", func(t *testing.T) {
+		// given
+		csv := newCSV(t, test.HostOperatorNs, "toolchain-host-operator.v0.0.1-123", "0.0.1-123")
+		copied := newCSV(t, test.HostOperatorNs, "global-operator.v1.2.3", "1.2.3")
+		copied.Labels = map[string]string{"olm.copiedFrom": "openshift-operators"}
+		newClient, _ := NewFakeClients(t, csv, copied, newToolchainStatusWithHostOperatorVersion("0.0.1"))
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+		errOut := &bytes.Buffer{}
+
+		// when
+		err := cmd.Version(ctx, "host", errOut)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, errOut.String())
+		assert.Contains(t, term.Output(), "ksctl commit: 'unknown', build time: 'unknown'")
+		assert.Contains(t, term.Output(), "toolchain-host-operator.v0.0.1-123: version '0.0.1-123', phase 'Succeeded'")
+		assert.NotContains(t, term.Output(), "global-operator")
+		assert.NotContains(t, term.Output(), "cool-token")
+	})
+
+	t.Run("without any CSV in the operator namespace", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		errOut := &bytes.Buffer{}
+
+		// when
+		err := cmd.Version(ctx, "member1", errOut)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "No ClusterServiceVersion found in the 'toolchain-member-operator' namespace of the 'member1' cluster")
+		assert.Contains(t, errOut.String(), "WARNING: the compatibility with the host operator could not be checked")
+	})
+
+	t.Run("with incompatible host operator version", func(t *testing.T) {
+		// given
+		version.MinHostOperatorVersion = "0.2.0"
+		t.Cleanup(func() {
+			version.MinHostOperatorVersion = "0.0.1"
+		})
+		csv := newCSV(t, test.MemberOperatorNs, "toolchain-member-operator.v0.1.0-42", "0.1.0-42")
+		newClient, _ := NewFakeClients(t, csv, newToolchainStatusWithHostOperatorVersion("0.1.0"))
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+		errOut := &bytes.Buffer{}
+
+		// when
+		err := cmd.Version(ctx, "member1", errOut)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, term.Output(), "toolchain-member-operator.v0.1.0-42: version '0.1.0-42', phase 'Succeeded'")
+		assert.NotContains(t, term.Output(), "WARNING")
+		assert.Equal(t, "WARNING: ksctl requires the host operator version '0.2.0' or later, but the host operator version is '0.1.0'\n", errOut.String())
+	})
+
+	t.Run("with unknown target cluster", func(t *testing.T) {
+		// given
+		newClient, _ := NewFakeClients(t)
+		term := NewFakeTerminal()
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		err := cmd.Version(ctx, "member2", &bytes.Buffer{})
+
+		// then
+		require.ErrorContains(t, err, "the provided cluster-name 'member2' is not present in your ksctl.yaml file")
+	})
+}
+
+func newToolchainStatusWithHostOperatorVersion(hostOperatorVersion string) *toolchainv1alpha1.ToolchainStatus {
+	status := NewToolchainStatus(ToBeReady())
+	status.Status.HostOperator = &toolchainv1alpha1.HostOperatorStatus{
+		Version: hostOperatorVersion,
+	}
+	return status
+}
+
+func newCSV(t *testing.T, namespace, name, version string) *olmv1alpha1.ClusterServiceVersion {
+	csv := &olmv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Status: olmv1alpha1.ClusterServiceVersionStatus{
+			Phase: olmv1alpha1.CSVPhaseSucceeded,
+		},
+	}
+	require.NoError(t, csv.Spec.Version.UnmarshalJSON([]byte(`"`+version+`"`)))
+	return csv
+}
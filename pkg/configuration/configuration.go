@@ -1,10 +1,16 @@
 package configuration
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/kubesaw/ksctl/pkg/ioutils"
 	"github.com/kubesaw/ksctl/pkg/utils"
@@ -12,11 +18,17 @@ import (
 	"github.com/mitchellh/go-homedir"
 	errs "github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 var (
 	ConfigFileFlag string
+	KubeconfigFlag string
 	Verbose        bool
+	// Quiet whether the banner showing the cluster targeted by the mutating commands should not be printed
+	Quiet bool
+	// Impersonate the user (or service account, as 'system:serviceaccount:<namespace>:<name>') to impersonate in the requests to the clusters
+	Impersonate string
 )
 
 type KsctlConfig struct {
@@ -26,29 +38,16 @@ type KsctlConfig struct {
 
 // Load reads in config file and ENV variables if set.
 func Load(term ioutils.Terminal) (KsctlConfig, error) {
-	path := ConfigFileFlag
-	if path == "" {
-		// Find home directory.
-		home, err := homedir.Dir()
-		if err != nil {
-			return KsctlConfig{}, errs.Wrap(err, "unable to read home directory")
-		}
-		path = filepath.Join(home, ".ksctl.yaml")
-
-		if _, err := os.Stat(path); err != nil && os.IsNotExist(err) {
-			if _, err := os.Stat(filepath.Join(home, ".sandbox.yaml")); err != nil && !os.IsNotExist(err) {
-				return KsctlConfig{}, err
-			} else if err == nil {
-				path = filepath.Join(home, ".sandbox.yaml")
-				term.Println("The default location of ~/.sandbox.yaml file is deprecated. Rename it to ~/.ksctl.yaml")
-			}
-		} else if err != nil {
-			return KsctlConfig{}, err
-		}
+	path, err := configFilePath(term)
+	if err != nil {
+		return KsctlConfig{}, err
 	}
 
 	info, err := os.Stat(path)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return KsctlConfig{}, NoClustersConfiguredError{Path: path}
+		}
 		return KsctlConfig{}, errs.Wrapf(err, "unable to read the file '%s'", path)
 	}
 	if info.IsDir() {
@@ -67,9 +66,129 @@ func Load(term ioutils.Terminal) (KsctlConfig, error) {
 	if err := yaml.Unmarshal(bytes, &ksctlConfig); err != nil {
 		return KsctlConfig{}, err
 	}
+	if len(ksctlConfig.ClusterAccessDefinitions) == 0 {
+		return KsctlConfig{}, NoClustersConfiguredError{Path: path}
+	}
+	registerTokens(ksctlConfig)
 	return ksctlConfig, nil
 }
 
+// configFilePath returns the path of the config file, either set with the `--config` flag or else in the home directory
+func configFilePath(term ioutils.Terminal) (string, error) {
+	if ConfigFileFlag != "" {
+		return ConfigFileFlag, nil
+	}
+	// Find home directory.
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", errs.Wrap(err, "unable to read home directory")
+	}
+	path := filepath.Join(home, ".ksctl.yaml")
+
+	if _, err := os.Stat(path); err != nil && os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(home, ".sandbox.yaml")); err != nil && !os.IsNotExist(err) {
+			return "", err
+		} else if err == nil {
+			path = filepath.Join(home, ".sandbox.yaml")
+			term.Println("The default location of ~/.sandbox.yaml file is deprecated. Rename it to ~/.ksctl.yaml")
+		}
+	} else if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// SetClusterToken replaces the token of the given cluster in the config file, keeping the rest of the file unchanged,
+// and returns the path of the updated config file
+func SetClusterToken(term ioutils.Terminal, clusterName, token string) (string, error) {
+	if KubeconfigFlag != "" {
+		return "", ioutils.Unsupportedf("the token cannot be set in the ksctl config file when the '--kubeconfig' flag is used")
+	}
+	path, err := configFilePath(term)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errs.Wrapf(err, "unable to read the file '%s'", path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(content, doc); err != nil {
+		return "", err
+	}
+	clusterDef := findClusterNode(doc, clusterName)
+	if clusterDef == nil {
+		return "", fmt.Errorf("the provided cluster-name '%s' is not present in your ksctl.yaml file", clusterName)
+	}
+	tokenNode := mappingValue(clusterDef, "token")
+	if tokenNode == nil {
+		tokenNode = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+		clusterDef.Content = append(clusterDef.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "token"}, tokenNode)
+	}
+	tokenNode.Value = token
+	tokenNode.Style = 0
+
+	updated := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(updated)
+	encoder.SetIndent(2) // same indentation as the generated config files
+	if err := encoder.Encode(doc); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	ioutils.RegisterSecrets(token)
+	return path, os.WriteFile(path, updated.Bytes(), info.Mode().Perm())
+}
+
+// findClusterNode returns the YAML node of the definition of the given cluster, looking up the name converted to camel case first
+// (the same way as loadClusterAccessDefinition), or nil if there is no such cluster
+func findClusterNode(doc *yaml.Node, clusterName string) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	for _, name := range []string{utils.KebabToCamelCase(clusterName), clusterName} {
+		if node := mappingValue(doc.Content[0], name); node != nil && node.Kind == yaml.MappingNode {
+			return node
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value of the given key in the given YAML mapping node, or nil if the key is not present
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// NoClustersConfiguredError is returned when the config file does not exist or when it doesn't contain any cluster
+type NoClustersConfiguredError struct {
+	Path string
+}
+
+func (e NoClustersConfiguredError) Error() string {
+	return fmt.Sprintf("there is no cluster configured in '%s'", e.Path)
+}
+
+// registerTokens registers all the tokens read from the config file, so they can be redacted from the terminal output
+// and from the error messages
+func registerTokens(ksctlConfig KsctlConfig) {
+	for _, clusterDef := range ksctlConfig.ClusterAccessDefinitions {
+		ioutils.RegisterSecrets(clusterDef.Token)
+	}
+}
+
 const HostName = "host"
 
 type ClusterType string
@@ -99,6 +218,8 @@ type ClusterDefinition struct {
 	ClusterType ClusterType `yaml:"clusterType"`
 	ServerAPI   string      `yaml:"serverAPI"`
 	ServerName  string      `yaml:"serverName"`
+	// OperatorNamespace the namespace where the operator of the cluster is deployed, when not the default one (optional)
+	OperatorNamespace string `yaml:"operatorNamespace,omitempty"`
 }
 
 type ClusterAccessDefinition struct {
@@ -140,11 +261,21 @@ func loadClusterAccessDefinition(ksctlConfig KsctlConfig, clusterName string) (C
 	return clusterDef, nil
 }
 
+// AllClusterNames returns the sorted names of all the clusters defined in the config file
+func AllClusterNames(term ioutils.Terminal) ([]string, error) {
+	ksctlConfig, err := Load(term)
+	if err != nil {
+		return nil, err
+	}
+	return getAllClusterNames(ksctlConfig), nil
+}
+
 func getAllClusterNames(config KsctlConfig) []string {
 	var clusterNames []string
 	for clusterName := range config.ClusterAccessDefinitions {
 		clusterNames = append(clusterNames, utils.CamelCaseToKebabCase(clusterName))
 	}
+	sort.Strings(clusterNames)
 	return clusterNames
 }
 
@@ -158,9 +289,25 @@ type ClusterConfig struct {
 	OperatorNamespace string // namespace where either the host-operator or the member-operator is deployed (depends on the cluster context)
 }
 
+// PrintTargetBanner prints a one-line banner with the name, the API server host and the given (operator) namespace of the cluster,
+// so the user can check where a mutating command will land before confirming it. Nothing is printed when Quiet is true.
+func (c ClusterConfig) PrintTargetBanner(term ioutils.Terminal, namespace string) {
+	if Quiet {
+		return
+	}
+	host := c.ServerAPI
+	if serverURL, err := url.Parse(c.ServerAPI); err == nil && serverURL.Host != "" {
+		host = serverURL.Host
+	}
+	term.Printlnf(">>> target cluster: '%s' (%s), namespace: '%s'", c.ClusterName, host, namespace)
+}
+
 // LoadClusterConfig loads ClusterConfig object from the config file and checks that all required parameters are set
 // as well as the token for the given name
 func LoadClusterConfig(term ioutils.Terminal, clusterName string) (ClusterConfig, error) {
+	if KubeconfigFlag != "" {
+		return loadClusterConfigFromKubeconfig(term, KubeconfigFlag, clusterName)
+	}
 	ksctlConfig, err := Load(term)
 	if err != nil {
 		return ClusterConfig{}, err
@@ -172,22 +319,15 @@ func LoadClusterConfig(term ioutils.Terminal, clusterName string) (ClusterConfig
 	if clusterDef.Token == "" {
 		return ClusterConfig{}, fmt.Errorf("ksctl command failed: the token in your ksctl.yaml file is missing")
 	}
-	var operatorNamespace string
-	if clusterName == HostName {
-		operatorNamespace = os.Getenv("HOST_OPERATOR_NAMESPACE")
-		if operatorNamespace == "" {
-			operatorNamespace = "toolchain-host-operator"
-		}
-	} else {
-		operatorNamespace = os.Getenv("MEMBER_OPERATOR_NAMESPACE")
-		if operatorNamespace == "" {
-			operatorNamespace = "toolchain-member-operator"
-		}
+	if err := checkTokenExpiry(clusterName, clusterDef.Token); err != nil {
+		return ClusterConfig{}, err
 	}
+	operatorNamespace := operatorNamespaceOf(clusterName, clusterDef.ClusterDefinition)
 
 	if Verbose {
 		term.Printlnf("Using '%s' configuration for '%s' cluster running at '%s' and in namespace '%s'\n",
 			clusterName, clusterDef.ServerName, clusterDef.ServerAPI, operatorNamespace)
+		printImpersonation(term)
 	}
 	return ClusterConfig{
 		ClusterAccessDefinition: clusterDef,
@@ -198,6 +338,153 @@ func LoadClusterConfig(term ioutils.Terminal, clusterName string) (ClusterConfig
 	}, nil
 }
 
+// LoadClusterConfigs loads the config file once and returns the ClusterConfig of all the clusters it contains, sorted by name.
+// Unlike LoadClusterConfig, the tokens are neither required nor checked
+func LoadClusterConfigs(term ioutils.Terminal) ([]ClusterConfig, error) {
+	ksctlConfig, err := Load(term)
+	if err != nil {
+		return nil, err
+	}
+	names := getAllClusterNames(ksctlConfig)
+	clusterConfigs := make([]ClusterConfig, 0, len(names))
+	for _, clusterName := range names {
+		clusterDef, err := loadClusterAccessDefinition(ksctlConfig, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		clusterConfigs = append(clusterConfigs, ClusterConfig{
+			ClusterAccessDefinition: clusterDef,
+			AllClusterNames:         names,
+			ClusterName:             clusterName,
+			Token:                   clusterDef.Token,
+			OperatorNamespace:       operatorNamespaceOf(clusterName, clusterDef.ClusterDefinition),
+		})
+	}
+	return clusterConfigs, nil
+}
+
+// loadClusterConfigFromKubeconfig loads ClusterConfig object from the context with the given cluster name of the given kubeconfig file
+func loadClusterConfigFromKubeconfig(term ioutils.Terminal, path, clusterName string) (ClusterConfig, error) {
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return ClusterConfig{}, errs.Wrapf(err, "unable to load the kubeconfig file '%s'", path)
+	}
+	contextNames := make([]string, 0, len(kubeconfig.Contexts))
+	for name := range kubeconfig.Contexts {
+		contextNames = append(contextNames, name)
+	}
+	sort.Strings(contextNames)
+	kubeContext, ok := kubeconfig.Contexts[clusterName]
+	if !ok {
+		return ClusterConfig{}, fmt.Errorf("the provided cluster-name '%s' is not a context of the '%s' kubeconfig file. The available contexts are\n"+
+			"------------------------\n%s\n"+
+			"------------------------", clusterName, path, strings.Join(contextNames, "\n"))
+	}
+	cluster, ok := kubeconfig.Clusters[kubeContext.Cluster]
+	if !ok || cluster.Server == "" {
+		return ClusterConfig{}, fmt.Errorf("ksctl command failed: The server API is not set for the context %s", clusterName)
+	}
+	var token string
+	if authInfo, ok := kubeconfig.AuthInfos[kubeContext.AuthInfo]; ok {
+		token = authInfo.Token
+		if token == "" && authInfo.TokenFile != "" {
+			content, err := os.ReadFile(authInfo.TokenFile)
+			if err != nil {
+				return ClusterConfig{}, errs.Wrapf(err, "unable to read the token file of the context '%s'", clusterName)
+			}
+			token = strings.TrimSpace(string(content))
+		}
+	}
+	if token == "" {
+		return ClusterConfig{}, fmt.Errorf("ksctl command failed: the token for the context '%s' in the '%s' kubeconfig file is missing", clusterName, path)
+	}
+	ioutils.RegisterSecrets(token)
+	if err := checkTokenExpiry(clusterName, token); err != nil {
+		return ClusterConfig{}, err
+	}
+	serverURL, err := url.Parse(cluster.Server)
+	if err != nil {
+		return ClusterConfig{}, errs.Wrapf(err, "invalid server API of the context '%s'", clusterName)
+	}
+	clusterType := Member
+	if clusterName == HostName {
+		clusterType = Host
+	}
+	operatorNamespace := OperatorNamespace(clusterName)
+
+	if Verbose {
+		term.Printlnf("Using '%s' context of the '%s' kubeconfig file for '%s' cluster running at '%s' and in namespace '%s'\n",
+			clusterName, path, serverURL.Hostname(), cluster.Server, operatorNamespace)
+		printImpersonation(term)
+	}
+	return ClusterConfig{
+		ClusterAccessDefinition: ClusterAccessDefinition{
+			ClusterDefinition: ClusterDefinition{
+				ClusterType: clusterType,
+				ServerAPI:   cluster.Server,
+				ServerName:  serverURL.Hostname(),
+			},
+			Token: token,
+		},
+		AllClusterNames:   contextNames,
+		ClusterName:       clusterName,
+		Token:             token,
+		OperatorNamespace: operatorNamespace,
+	}, nil
+}
+
+func printImpersonation(term ioutils.Terminal) {
+	if Impersonate != "" {
+		term.Printlnf("Impersonating '%s' in the requests to the cluster\n", Impersonate)
+	}
+}
+
+// checkTokenExpiry returns an error if the given token is a JWT whose 'exp' claim is in the past.
+// Tokens which are not JWTs (or without the 'exp' claim) are not checked here: their expiry is only detected
+// when the API server rejects them while creating the client (see client.NewClient).
+func checkTokenExpiry(clusterName, token string) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+	claims := struct {
+		Exp *int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return nil
+	}
+	if expiry := time.Unix(*claims.Exp, 0); expiry.Before(time.Now()) {
+		return fmt.Errorf("ksctl command failed: the token for the cluster '%s' appears to be expired since %s, please refresh it (eg, with 'ksctl adm set-token -t %s')", clusterName, expiry.UTC().Format(time.RFC3339), clusterName)
+	}
+	return nil
+}
+
+func operatorNamespaceOf(clusterName string, clusterDef ClusterDefinition) string {
+	if clusterDef.OperatorNamespace != "" {
+		return clusterDef.OperatorNamespace
+	}
+	return OperatorNamespace(clusterName)
+}
+
+// OperatorNamespace returns the default namespace where the operator of the cluster with the given name is deployed,
+// which can be overridden by the HOST_OPERATOR_NAMESPACE and MEMBER_OPERATOR_NAMESPACE env vars
+func OperatorNamespace(clusterName string) string {
+	if clusterName == HostName {
+		if operatorNamespace := os.Getenv("HOST_OPERATOR_NAMESPACE"); operatorNamespace != "" {
+			return operatorNamespace
+		}
+		return "toolchain-host-operator"
+	}
+	if operatorNamespace := os.Getenv("MEMBER_OPERATOR_NAMESPACE"); operatorNamespace != "" {
+		return operatorNamespace
+	}
+	return "toolchain-member-operator"
+}
+
 // GetServerParam returns the `--server=` param along with its actual value
 func (c ClusterConfig) GetServerParam() string {
 	return "--server=" + c.ServerAPI
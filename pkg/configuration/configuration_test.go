@@ -1,10 +1,12 @@
 package configuration_test
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/codeready-toolchain/toolchain-common/pkg/test"
 	"github.com/kubesaw/ksctl/pkg/configuration"
@@ -12,6 +14,7 @@ import (
 	"github.com/kubesaw/ksctl/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func TestLoadClusterConfig(t *testing.T) {
@@ -184,8 +187,8 @@ func TestLoadClusterConfig(t *testing.T) {
 				_, err := configuration.LoadClusterConfig(term, "dummy")
 
 				// then
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), "the provided cluster-name 'dummy' is not present in your ksctl.yaml file. The available cluster names are")
+				require.EqualError(t, err, fmt.Sprintf("there is no cluster configured in '%s'", configuration.ConfigFileFlag))
+				assert.ErrorAs(t, err, &configuration.NoClustersConfiguredError{})
 			})
 
 			for _, clusterConfigParam := range []ClusterDefinitionWithName{Host(), Member()} {
@@ -222,12 +225,257 @@ func TestLoadingClusterConfigWithNonexistentClusterName(t *testing.T) {
 
 	// then
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "the provided cluster-name 'dummy' is not present in your ksctl.yaml file. The available cluster names are")
-	assert.Contains(t, err.Error(), "host")
-	assert.Contains(t, err.Error(), "member-1")
+	assert.Contains(t, err.Error(), "the provided cluster-name 'dummy' is not present in your ksctl.yaml file. The available cluster names are\n"+
+		"------------------------\nhost\nmember-1\n------------------------")
 	assert.Empty(t, cfg.OperatorNamespace)
 }
 
+func TestLoadClusterConfigWithImpersonation(t *testing.T) {
+	// given
+	SetFileConfig(t, Host())
+	configuration.Impersonate = "system:serviceaccount:ksctl:reader"
+	configuration.Verbose = true
+	t.Cleanup(func() {
+		configuration.Impersonate = ""
+		configuration.Verbose = false
+	})
+	term := NewFakeTerminal()
+
+	// when
+	_, err := configuration.LoadClusterConfig(term, "host")
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, term.Output(), "Impersonating 'system:serviceaccount:ksctl:reader' in the requests to the cluster")
+}
+
+func TestLoadClusterConfigs(t *testing.T) {
+	operatorNamespaces := func(clusterConfigs []configuration.ClusterConfig) map[string]string {
+		namespaces := map[string]string{}
+		for _, cfg := range clusterConfigs {
+			namespaces[cfg.ClusterName] = cfg.OperatorNamespace
+		}
+		return namespaces
+	}
+
+	t.Run("default namespaces", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host(), Member())
+		term := NewFakeTerminal()
+
+		// when
+		clusterConfigs, err := configuration.LoadClusterConfigs(term)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, clusterConfigs, 2)
+		assert.Equal(t, "host", clusterConfigs[0].ClusterName)
+		assert.Equal(t, configuration.Host, clusterConfigs[0].ClusterType)
+		assert.Equal(t, []string{"host", "member-1"}, clusterConfigs[0].AllClusterNames)
+		assert.Equal(t, "member-1", clusterConfigs[1].ClusterName)
+		assert.Equal(t, configuration.Member, clusterConfigs[1].ClusterType)
+		assert.Equal(t, map[string]string{
+			"host":     "toolchain-host-operator",
+			"member-1": "toolchain-member-operator",
+		}, operatorNamespaces(clusterConfigs))
+	})
+
+	t.Run("namespaces set by env vars", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host(), Member())
+		t.Setenv("HOST_OPERATOR_NAMESPACE", "custom-host-operator")
+		t.Setenv("MEMBER_OPERATOR_NAMESPACE", "custom-member-operator")
+		term := NewFakeTerminal()
+
+		// when
+		clusterConfigs, err := configuration.LoadClusterConfigs(term)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"host":     "custom-host-operator",
+			"member-1": "custom-member-operator",
+		}, operatorNamespaces(clusterConfigs))
+	})
+
+	t.Run("namespaces set per cluster in the config file", func(t *testing.T) {
+		// given
+		SetFileConfig(t,
+			Host(OperatorNamespace("sandbox-host")),
+			Member(OperatorNamespace("sandbox-member-1")),
+			Member(ClusterName("member2")))
+		t.Setenv("MEMBER_OPERATOR_NAMESPACE", "custom-member-operator") // only used for the clusters without namespace in the config file
+		term := NewFakeTerminal()
+
+		// when
+		clusterConfigs, err := configuration.LoadClusterConfigs(term)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"host":     "sandbox-host",
+			"member-1": "sandbox-member-1",
+			"member-2": "custom-member-operator",
+		}, operatorNamespaces(clusterConfigs))
+
+		t.Run("used by the loaded cluster config", func(t *testing.T) {
+			// when
+			cfg, err := configuration.LoadClusterConfig(term, "member1")
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, "sandbox-member-1", cfg.OperatorNamespace)
+			assert.Equal(t, "--namespace=sandbox-member-1", cfg.GetNamespaceParam())
+		})
+	})
+
+	t.Run("tokens are not required", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host(NoToken()))
+		term := NewFakeTerminal()
+
+		// when
+		clusterConfigs, err := configuration.LoadClusterConfigs(term)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, clusterConfigs, 1)
+		assert.Empty(t, clusterConfigs[0].Token)
+	})
+}
+
+func TestLoadClusterConfigWithJWTToken(t *testing.T) {
+	newJWT := func(claims string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+	}
+
+	t.Run("when token is expired", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host(Token(newJWT(`{"sub":"john","exp":1577836800}`))))
+
+		// when
+		_, err := configuration.LoadClusterConfig(NewFakeTerminal(), "host")
+
+		// then
+		require.EqualError(t, err, "ksctl command failed: the token for the cluster 'host' appears to be expired since 2020-01-01T00:00:00Z, please refresh it (eg, with 'ksctl adm set-token -t host')")
+	})
+
+	t.Run("when token is not expired yet", func(t *testing.T) {
+		// given
+		token := newJWT(fmt.Sprintf(`{"sub":"john","exp":%d}`, time.Now().Add(time.Hour).Unix()))
+		SetFileConfig(t, Host(Token(token)))
+
+		// when
+		cfg, err := configuration.LoadClusterConfig(NewFakeTerminal(), "host")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, token, cfg.Token)
+	})
+
+	t.Run("when token has no expiry", func(t *testing.T) {
+		// given
+		token := newJWT(`{"sub":"john"}`)
+		SetFileConfig(t, Host(Token(token)))
+
+		// when
+		cfg, err := configuration.LoadClusterConfig(NewFakeTerminal(), "host")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, token, cfg.Token)
+	})
+}
+
+func TestAllClusterNames(t *testing.T) {
+	t.Run("returns sorted names", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Member(ClusterName("member-2")), Host(), Member())
+
+		// when
+		names, err := configuration.AllClusterNames(NewFakeTerminal())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"host", "member-1", "member-2"}, names)
+	})
+
+	t.Run("fails when no cluster is configured", func(t *testing.T) {
+		// given
+		SetFileConfig(t)
+
+		// when
+		_, err := configuration.AllClusterNames(NewFakeTerminal())
+
+		// then
+		require.ErrorAs(t, err, &configuration.NoClustersConfiguredError{})
+	})
+}
+
+func TestLoadClusterConfigFromKubeconfig(t *testing.T) {
+	// given
+	kubeconfig := HostKubeConfig()
+	kubeconfig.Contexts["host"].AuthInfo = "john"
+	kubeconfig.AuthInfos["john"] = &clientcmdapi.AuthInfo{Token: "kube-token"}
+	kubeconfig.Clusters["member-1"] = &clientcmdapi.Cluster{Server: "https://api.member.com:6443"}
+	kubeconfig.Contexts["member-1"] = &clientcmdapi.Context{Cluster: "member-1", AuthInfo: "john"}
+	kubeconfig.Contexts["no-token"] = &clientcmdapi.Context{Cluster: "member-1"}
+	configuration.KubeconfigFlag = PersistKubeConfigFile(t, kubeconfig)
+	t.Cleanup(func() {
+		configuration.KubeconfigFlag = ""
+	})
+	// the ksctl config file is not used at all
+	configuration.ConfigFileFlag = "/tmp/should-not-exist.yaml"
+	t.Cleanup(func() {
+		configuration.ConfigFileFlag = ""
+	})
+
+	t.Run("for host", func(t *testing.T) {
+		// when
+		cfg, err := configuration.LoadClusterConfig(NewFakeTerminal(), "host")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, configuration.Host, cfg.ClusterType)
+		assert.Equal(t, "https://cool-server.com", cfg.ServerAPI)
+		assert.Equal(t, "cool-server.com", cfg.ServerName)
+		assert.Equal(t, "kube-token", cfg.Token)
+		assert.Equal(t, "toolchain-host-operator", cfg.OperatorNamespace)
+		assert.Equal(t, []string{"host", "member-1", "no-token"}, cfg.AllClusterNames)
+	})
+
+	t.Run("for member", func(t *testing.T) {
+		// when
+		cfg, err := configuration.LoadClusterConfig(NewFakeTerminal(), "member-1")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, configuration.Member, cfg.ClusterType)
+		assert.Equal(t, "https://api.member.com:6443", cfg.ServerAPI)
+		assert.Equal(t, "api.member.com", cfg.ServerName)
+		assert.Equal(t, "kube-token", cfg.Token)
+		assert.Equal(t, "toolchain-member-operator", cfg.OperatorNamespace)
+	})
+
+	t.Run("when context does not exist", func(t *testing.T) {
+		// when
+		_, err := configuration.LoadClusterConfig(NewFakeTerminal(), "dummy")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("the provided cluster-name 'dummy' is not a context of the '%s' kubeconfig file. The available contexts are", configuration.KubeconfigFlag))
+		assert.Contains(t, err.Error(), "member-1")
+	})
+
+	t.Run("when token is missing", func(t *testing.T) {
+		// when
+		_, err := configuration.LoadClusterConfig(NewFakeTerminal(), "no-token")
+
+		// then
+		require.EqualError(t, err, fmt.Sprintf("ksctl command failed: the token for the context 'no-token' in the '%s' kubeconfig file is missing", configuration.KubeconfigFlag))
+	})
+}
+
 func TestLoad(t *testing.T) {
 
 	t.Run("with verbose messages", func(t *testing.T) {
@@ -274,7 +522,8 @@ func TestLoadFails(t *testing.T) {
 		_, err := configuration.Load(term)
 
 		// then
-		require.Error(t, err)
+		require.EqualError(t, err, "there is no cluster configured in '/tmp/should-not-exist.yaml'")
+		assert.ErrorAs(t, err, &configuration.NoClustersConfiguredError{})
 	})
 
 	t.Run("file is directory", func(t *testing.T) {
@@ -313,3 +562,71 @@ func TestAsSuffix(t *testing.T) {
 		assert.Equal(t, "prefix-member", result)
 	})
 }
+
+func TestPrintTargetBanner(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(ServerAPI("https://api.host.com:6443")))
+	cfg, err := configuration.LoadClusterConfig(NewFakeTerminal(), "host")
+	require.NoError(t, err)
+
+	t.Run("banner is printed", func(t *testing.T) {
+		// given
+		term := NewFakeTerminal()
+
+		// when
+		cfg.PrintTargetBanner(term, "toolchain-host-operator")
+
+		// then
+		assert.Equal(t, ">>> target cluster: 'host' (api.host.com:6443), namespace: 'toolchain-host-operator'\n", term.Output())
+		assert.NotContains(t, term.Output(), "cool-token")
+	})
+
+	t.Run("banner is not printed when quiet", func(t *testing.T) {
+		// given
+		term := NewFakeTerminal()
+		configuration.Quiet = true
+		t.Cleanup(func() {
+			configuration.Quiet = false
+		})
+
+		// when
+		cfg.PrintTargetBanner(term, "toolchain-host-operator")
+
+		// then
+		assert.Empty(t, term.Output())
+	})
+}
+
+func TestSetClusterToken(t *testing.T) {
+	t.Run("token is replaced", func(t *testing.T) {
+		// given
+		SetFileConfig(t, Host(), Member())
+		term := NewFakeTerminal()
+
+		// when
+		path, err := configuration.SetClusterToken(term, "member-1", "new-token")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, configuration.ConfigFileFlag, path)
+		ksctlConfig, err := configuration.Load(term)
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", ksctlConfig.ClusterAccessDefinitions["member1"].Token)
+		assert.Equal(t, "cool-token", ksctlConfig.ClusterAccessDefinitions["host"].Token)
+		assert.Equal(t, "cool-server.com", ksctlConfig.ClusterAccessDefinitions["member1"].ServerName)
+	})
+
+	t.Run("unsupported with kubeconfig", func(t *testing.T) {
+		// given
+		configuration.KubeconfigFlag = "/path/to/kubeconfig"
+		t.Cleanup(func() {
+			configuration.KubeconfigFlag = ""
+		})
+
+		// when
+		_, err := configuration.SetClusterToken(NewFakeTerminal(), "host", "new-token")
+
+		// then
+		require.EqualError(t, err, "the token cannot be set in the ksctl config file when the '--kubeconfig' flag is used")
+	})
+}
@@ -1,6 +1,7 @@
 package context
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/kubesaw/ksctl/pkg/assets"
@@ -20,8 +21,9 @@ type ClusterConfigCommandContext struct {
 func NewClusterConfigCommandContext(term ioutils.Terminal, cfg configuration.ClusterConfig, newClient NewClientFunc, files assets.FS, clusterConfigName string) *ClusterConfigCommandContext {
 	return &ClusterConfigCommandContext{
 		CommandContext: CommandContext{
+			Context:   context.Background(),
 			Terminal:  term,
-			NewClient: newClient,
+			newClient: newClient,
 		},
 		Files:             files,
 		ClusterConfig:     cfg,
@@ -3,6 +3,7 @@ package context
 import (
 	"context"
 
+	"github.com/kubesaw/ksctl/pkg/configuration"
 	"github.com/kubesaw/ksctl/pkg/ioutils"
 
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,17 +13,81 @@ import (
 type CommandContext struct {
 	context.Context
 	ioutils.Terminal
-	NewClient NewClientFunc
+	newClient NewClientFunc
+	// target the cluster and the namespace where the command applies its changes, printed before the first confirmation
+	target *commandTarget
 }
 
-// NewClientFunc a function to create a `client.Client` with the given token and API endpoint
-type NewClientFunc func(string, string) (runtimeclient.Client, error)
+type commandTarget struct {
+	cfg           configuration.ClusterConfig
+	namespace     string
+	bannerPrinted bool
+}
+
+// NewClientFunc a function to create a `client.Client` with the given token and API endpoint.
+// The creation is abandoned when the given context is done
+type NewClientFunc func(context.Context, string, string) (runtimeclient.Client, error)
 
 // NewCommandContext returns the context of the command to run
 func NewCommandContext(term ioutils.Terminal, newClient NewClientFunc) *CommandContext {
 	return &CommandContext{
 		Context:   context.Background(),
 		Terminal:  term,
-		NewClient: newClient,
+		newClient: newClient,
+	}
+}
+
+// NewClient creates a `client.Client` with the given token and API endpoint, using the context of the command,
+// so the creation (and its retries) is abandoned when the command is interrupted
+func (ctx *CommandContext) NewClient(token, apiEndpoint string) (runtimeclient.Client, error) {
+	return ctx.newClient(ctx.Context, token, apiEndpoint)
+}
+
+// WithContext returns a copy of this command context which uses the given context.Context for the API calls,
+// so they are cancelled when the given context is (eg, when the command is interrupted)
+func (ctx *CommandContext) WithContext(c context.Context) *CommandContext {
+	copied := *ctx
+	copied.Context = c
+	return &copied
+}
+
+// SetTarget sets the cluster and the namespace where the command applies its changes. The target banner
+// (see configuration.ClusterConfig.PrintTargetBanner) is then printed before the first confirmation asked to the user
+func (ctx *CommandContext) SetTarget(cfg configuration.ClusterConfig, namespace string) {
+	if ctx.target != nil && ctx.target.cfg.ClusterName == cfg.ClusterName && ctx.target.namespace == namespace {
+		// same target, so the banner is not printed again
+		return
+	}
+	ctx.target = &commandTarget{
+		cfg:       cfg,
+		namespace: namespace,
+	}
+}
+
+// AskForConfirmation prints the target banner (if not done yet) and asks the user to answer y or n to the given message
+func (ctx *CommandContext) AskForConfirmation(msg ioutils.ConfirmationMessage) bool {
+	ctx.printTargetBanner()
+	return ctx.Terminal.AskForConfirmation(msg)
+}
+
+// AskForConfirmationWithDefault prints the target banner (if not done yet) and asks the user to answer y or n to the given message,
+// using the given default answer when the user just presses Enter
+func (ctx *CommandContext) AskForConfirmationWithDefault(msg ioutils.ConfirmationMessage, defaultAnswer bool) bool {
+	ctx.printTargetBanner()
+	return ctx.Terminal.AskForConfirmationWithDefault(msg, defaultAnswer)
+}
+
+// AskForTypedConfirmation prints the target banner (if not done yet) and asks the user to confirm the given message
+// by typing the expected value
+func (ctx *CommandContext) AskForTypedConfirmation(msg ioutils.ConfirmationMessage, expected string) bool {
+	ctx.printTargetBanner()
+	return ctx.Terminal.AskForTypedConfirmation(msg, expected)
+}
+
+func (ctx *CommandContext) printTargetBanner() {
+	if ctx.target == nil || ctx.target.bannerPrinted {
+		return
 	}
+	ctx.target.cfg.PrintTargetBanner(ctx.Terminal, ctx.target.namespace)
+	ctx.target.bannerPrinted = true
 }
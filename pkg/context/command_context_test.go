@@ -1,12 +1,15 @@
 package context_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/kubesaw/ksctl/pkg/configuration"
 	clicontext "github.com/kubesaw/ksctl/pkg/context"
+	"github.com/kubesaw/ksctl/pkg/ioutils"
 	. "github.com/kubesaw/ksctl/pkg/test"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,3 +43,78 @@ func TestLoadClusterConfig(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestTargetBanner(t *testing.T) {
+	// given
+	SetFileConfig(t, Host(), Member())
+	newClient, _ := NewFakeClients(t)
+
+	t.Run("printed once before the confirmations", func(t *testing.T) {
+		// given
+		term := NewFakeTerminalWithResponse("y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+		cfg, err := configuration.LoadClusterConfig(ctx, "host")
+		require.NoError(t, err)
+
+		// when
+		ctx.SetTarget(cfg, "toolchain-host-operator")
+		ctx.AskForConfirmation(ioutils.WithMessagef("do something?"))
+		ctx.SetTarget(cfg, "toolchain-host-operator") // same target
+		ctx.AskForConfirmation(ioutils.WithMessagef("do something else?"))
+
+		// then
+		assert.Equal(t, 1, strings.Count(term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'"))
+		assert.Less(t, strings.Index(term.Output(), ">>> target cluster"), strings.Index(term.Output(), "do something?"))
+	})
+
+	t.Run("printed again for another target", func(t *testing.T) {
+		// given
+		term := NewFakeTerminalWithResponse("y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+		hostCfg, err := configuration.LoadClusterConfig(ctx, "host")
+		require.NoError(t, err)
+		memberCfg, err := configuration.LoadClusterConfig(ctx, "member1")
+		require.NoError(t, err)
+
+		// when
+		ctx.SetTarget(hostCfg, "toolchain-host-operator")
+		ctx.AskForTypedConfirmation(ioutils.WithMessagef("do something?"), "y")
+		ctx.SetTarget(memberCfg, "toolchain-member-operator")
+		ctx.AskForConfirmationWithDefault(ioutils.WithMessagef("do something else?"), true)
+
+		// then
+		assert.Contains(t, term.Output(), ">>> target cluster: 'host' (cool-server.com), namespace: 'toolchain-host-operator'")
+		assert.Contains(t, term.Output(), ">>> target cluster: 'member1' (cool-server.com), namespace: 'toolchain-member-operator'")
+	})
+
+	t.Run("not printed without target", func(t *testing.T) {
+		// given
+		term := NewFakeTerminalWithResponse("y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+
+		// when
+		ctx.AskForConfirmation(ioutils.WithMessagef("do something?"))
+
+		// then
+		assert.NotContains(t, term.Output(), ">>> target cluster")
+	})
+
+	t.Run("not printed when quiet", func(t *testing.T) {
+		// given
+		configuration.Quiet = true
+		t.Cleanup(func() {
+			configuration.Quiet = false
+		})
+		term := NewFakeTerminalWithResponse("y")
+		ctx := clicontext.NewCommandContext(term, newClient)
+		cfg, err := configuration.LoadClusterConfig(ctx, "host")
+		require.NoError(t, err)
+
+		// when
+		ctx.SetTarget(cfg, "toolchain-host-operator")
+		ctx.AskForConfirmation(ioutils.WithMessagef("do something?"))
+
+		// then
+		assert.NotContains(t, term.Output(), ">>> target cluster")
+	})
+}
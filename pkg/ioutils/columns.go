@@ -0,0 +1,58 @@
+package ioutils
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// customColumnsPrefix the prefix of the output format selecting the columns of a list, eg. `custom-columns=name,ready`
+const customColumnsPrefix = "custom-columns="
+
+// CustomColumns returns the columns selected with the `custom-columns=<column>[,<column>...]` output format,
+// or nil if the given output format is not a custom-columns one.
+// Returns an UnsupportedError if no column is selected or if a column is not among the supported ones.
+func CustomColumns(output string, supported ...string) ([]string, error) {
+	if !strings.HasPrefix(output, customColumnsPrefix) {
+		return nil, nil
+	}
+	columns := []string{}
+	for _, column := range strings.Split(strings.TrimPrefix(output, customColumnsPrefix), ",") {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			continue
+		}
+		if !contains(supported, column) {
+			return nil, Unsupportedf("unsupported column '%s', the supported ones are: %s", column, strings.Join(supported, ", "))
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, Unsupportedf("no column selected, the supported ones are: %s", strings.Join(supported, ", "))
+	}
+	return columns, nil
+}
+
+// PrintColumns prints the given rows as a table with the given columns, the header of each column being its name in upper case.
+// The value of each cell is returned by the given function.
+func PrintColumns(term Terminal, columns []string, rows int, cell func(row int, column string) string) error {
+	w := tabwriter.NewWriter(term.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(columns, "\t")))
+	for row := 0; row < rows; row++ {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, cell(row, column))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
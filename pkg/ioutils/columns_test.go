@@ -0,0 +1,70 @@
+package ioutils_test
+
+import (
+	"testing"
+
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomColumns(t *testing.T) {
+	t.Run("selected columns", func(t *testing.T) {
+		// when
+		columns, err := ioutils.CustomColumns("custom-columns=name, ready", "name", "ready", "replicas")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"name", "ready"}, columns)
+	})
+
+	t.Run("not a custom-columns output format", func(t *testing.T) {
+		for _, output := range []string{"", "json", "yaml"} {
+			// when
+			columns, err := ioutils.CustomColumns(output, "name")
+
+			// then
+			require.NoError(t, err)
+			assert.Nil(t, columns)
+		}
+	})
+
+	t.Run("unsupported column", func(t *testing.T) {
+		// when
+		_, err := ioutils.CustomColumns("custom-columns=name,age", "name", "ready")
+
+		// then
+		require.EqualError(t, err, "unsupported column 'age', the supported ones are: name, ready")
+		assert.ErrorAs(t, err, &ioutils.UnsupportedError{})
+	})
+
+	t.Run("no column", func(t *testing.T) {
+		// when
+		_, err := ioutils.CustomColumns("custom-columns=", "name", "ready")
+
+		// then
+		require.EqualError(t, err, "no column selected, the supported ones are: name, ready")
+	})
+}
+
+func TestPrintColumns(t *testing.T) {
+	// given
+	term := NewFakeTerminal()
+	rows := [][]string{{"host-operator", "1/1"}, {"registration-service", "2/3"}}
+
+	// when
+	err := ioutils.PrintColumns(term, []string{"name", "ready"}, len(rows), func(row int, column string) string {
+		if column == "ready" {
+			return rows[row][1]
+		}
+		return rows[row][0]
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "NAME                   READY\n"+
+		"host-operator          1/1\n"+
+		"registration-service   2/3\n", term.Output())
+}
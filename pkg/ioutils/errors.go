@@ -0,0 +1,56 @@
+package ioutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnsupportedError is returned when the requested feature, option or resource is not supported by ksctl
+type UnsupportedError struct {
+	msg string
+}
+
+func (e UnsupportedError) Error() string {
+	return e.msg
+}
+
+// Unsupportedf returns an UnsupportedError with the given message
+func Unsupportedf(format string, args ...interface{}) error {
+	return UnsupportedError{
+		msg: fmt.Sprintf(format, args...),
+	}
+}
+
+// ValidateOutputFormat returns an UnsupportedError if the given output format is neither empty nor 'json'
+func ValidateOutputFormat(output string) error {
+	return ValidateOutputFormatOneOf(output, "json")
+}
+
+// ValidateOutputFormatOneOf returns an UnsupportedError if the given output format is neither empty nor one of the supported formats
+func ValidateOutputFormatOneOf(output string, supported ...string) error {
+	if output == "" {
+		return nil
+	}
+	for _, format := range supported {
+		if output == format {
+			return nil
+		}
+	}
+	if len(supported) == 1 {
+		return Unsupportedf("unsupported output format '%s', the only supported format is '%s'", output, supported[0])
+	}
+	return Unsupportedf("unsupported output format '%s', the supported formats are '%s'", output, strings.Join(supported, "', '"))
+}
+
+// confirmationDeclined whether the user declined a confirmation since the last call of ResetConfirmationDeclined
+var confirmationDeclined bool
+
+// ConfirmationDeclined returns true if the user declined a confirmation since the last call of ResetConfirmationDeclined
+func ConfirmationDeclined() bool {
+	return confirmationDeclined
+}
+
+// ResetConfirmationDeclined forgets about any confirmation declined by the user so far
+func ResetConfirmationDeclined() {
+	confirmationDeclined = false
+}
@@ -0,0 +1,47 @@
+package ioutils_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOutputFormatOneOf(t *testing.T) {
+	t.Run("empty format", func(t *testing.T) {
+		// when
+		err := ioutils.ValidateOutputFormatOneOf("", "yaml")
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("supported format", func(t *testing.T) {
+		// when
+		err := ioutils.ValidateOutputFormatOneOf("yaml", "json", "yaml")
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported format with a single supported format", func(t *testing.T) {
+		// when
+		err := ioutils.ValidateOutputFormatOneOf("json", "yaml")
+
+		// then
+		require.EqualError(t, err, "unsupported output format 'json', the only supported format is 'yaml'")
+		assert.True(t, errors.As(err, &ioutils.UnsupportedError{}))
+	})
+
+	t.Run("unsupported format with several supported formats", func(t *testing.T) {
+		// when
+		err := ioutils.ValidateOutputFormatOneOf("wide", "json", "yaml")
+
+		// then
+		require.EqualError(t, err, "unsupported output format 'wide', the supported formats are 'json', 'yaml'")
+		assert.True(t, errors.As(err, &ioutils.UnsupportedError{}))
+	})
+}
@@ -0,0 +1,52 @@
+package ioutils
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// secrets contains all the values (eg, the tokens read from the config file) that should never be displayed
+var secrets = struct {
+	sync.RWMutex
+	values map[string]struct{}
+}{values: map[string]struct{}{}}
+
+// RegisterSecrets registers the given values so they are redacted from the terminal output and from the messages
+// passed to RedactSecrets
+func RegisterSecrets(values ...string) {
+	secrets.Lock()
+	defer secrets.Unlock()
+	for _, value := range values {
+		if value != "" {
+			secrets.values[value] = struct{}{}
+		}
+	}
+}
+
+// RedactSecrets replaces all the registered secrets that are present in the given message
+func RedactSecrets(msg string) string {
+	secrets.RLock()
+	defer secrets.RUnlock()
+	for secret := range secrets.values {
+		msg = strings.ReplaceAll(msg, secret, "*****")
+	}
+	return msg
+}
+
+// RedactingWriter returns an io.Writer which redacts the registered secrets before writing to the given writer
+func RedactingWriter(out io.Writer) io.Writer {
+	return redactingWriter{out: out}
+}
+
+// redactingWriter an io.Writer which redacts the registered secrets before writing to the underlying writer
+type redactingWriter struct {
+	out io.Writer
+}
+
+func (w redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.out, RedactSecrets(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
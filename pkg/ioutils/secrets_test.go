@@ -0,0 +1,59 @@
+package ioutils_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/kubesaw/ksctl/pkg/ioutils"
+	. "github.com/kubesaw/ksctl/pkg/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	// given
+	ioutils.RegisterSecrets("my-secret-token", "")
+
+	t.Run("in message", func(t *testing.T) {
+		// when
+		msg := ioutils.RedactSecrets("unauthorized: Bearer my-secret-token")
+
+		// then
+		assert.Equal(t, "unauthorized: Bearer *****", msg)
+	})
+
+	t.Run("in terminal output", func(t *testing.T) {
+		// given
+		term := NewFakeTerminal()
+
+		// when
+		term.Printlnf("using token '%s' on %s", "my-secret-token", "host")
+		term.Println("my-secret-token again")
+
+		// then
+		assert.Equal(t, "using token '*****' on host\n***** again\n", term.Output())
+	})
+
+	t.Run("in writer", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+
+		// when
+		n, err := io.WriteString(ioutils.RedactingWriter(out), "token: my-secret-token")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, len("token: my-secret-token"), n)
+		assert.Equal(t, "token: *****", out.String())
+	})
+
+	t.Run("message without secret is unchanged", func(t *testing.T) {
+		// when
+		msg := ioutils.RedactSecrets("nothing to hide")
+
+		// then
+		assert.Equal(t, "nothing to hide", msg)
+	})
+}
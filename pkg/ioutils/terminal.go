@@ -2,9 +2,10 @@ package ioutils
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"os"
 	"strings"
 
 	"github.com/ghodss/yaml"
@@ -17,12 +18,18 @@ import (
 // AssumeYes automatically answers yes for all questions.
 var AssumeYes bool
 
+// ConfirmationPrefix the prefix of all the lines printed when asking for a confirmation,
+// so the confirmations and their answers can be extracted from the logs.
+var ConfirmationPrefix string
+
 // Terminal a wrapper around a Cobra command, with extra methods
 // to display messages.
 type Terminal interface {
 	InOrStdin() io.Reader
 	OutOrStdout() io.Writer
 	AskForConfirmation(msg ConfirmationMessage) bool
+	AskForConfirmationWithDefault(msg ConfirmationMessage, defaultAnswer bool) bool
+	AskForTypedConfirmation(msg ConfirmationMessage, expected string) bool
 	Println(msg string)
 	Printlnf(msg string, args ...interface{})
 	PrintContextSeparatorf(context string, args ...interface{})
@@ -51,9 +58,10 @@ func (t *DefaultTerminal) InOrStdin() io.Reader {
 	return t.in()
 }
 
-// OutOrStdout returns an `io.Writer` to write messages in the console
+// OutOrStdout returns an `io.Writer` to write messages in the console.
+// All the registered secrets are redacted from the written messages.
 func (t *DefaultTerminal) OutOrStdout() io.Writer {
-	return t.out()
+	return RedactingWriter(t.out())
 }
 
 // Println prints the given message and appends a line feed
@@ -108,6 +116,32 @@ func (t *DefaultTerminal) PrintObject(object runtime.Object, title string) error
 	return nil
 }
 
+// PrintJSON prints the given value as indented JSON
+func PrintJSON(term Terminal, value interface{}) error {
+	result, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errs.Wrapf(err, "unable to marshal %+v", value)
+	}
+	term.Println(string(result))
+	return nil
+}
+
+// PrintYAML prints the given value as YAML
+func PrintYAML(term Terminal, value interface{}) error {
+	result, err := yaml.Marshal(value)
+	if err != nil {
+		return errs.Wrapf(err, "unable to marshal %+v", value)
+	}
+	term.Printlnf("%s", strings.TrimSuffix(string(result), "\n"))
+	return nil
+}
+
+// PrintNothingToDo prints the given reason why the command is a no-op, in a consistent way for all the commands,
+// which are then expected to return without asking for any confirmation
+func PrintNothingToDo(term Terminal, reason string, args ...interface{}) {
+	term.Printlnf("%s: already in desired state; nothing to do", fmt.Sprintf(reason, args...))
+}
+
 func WithDangerZoneMessagef(consequence, action string, args ...interface{}) ConfirmationMessage {
 	return ConfirmationMessage(fmt.Sprintf(`
 ###################################
@@ -127,29 +161,115 @@ Are you sure that you want to %s`, fmt.Sprintf(action, args...)))
 
 type ConfirmationMessage string
 
+// AskForConfirmation asks the user to answer y or n to the given message
 func (t *DefaultTerminal) AskForConfirmation(msg ConfirmationMessage) bool {
-	reader := bufio.NewReader(t.InOrStdin())
-	t.Printlnf(string(msg))
-	t.Printlnf("===============================")
-	t.Printf("[y/n] -> ")
-	text := ""
-	var err error
+	return t.askForConfirmation(msg, "[y/n]", "")
+}
+
+// AskForConfirmationWithDefault asks the user to answer y or n to the given message,
+// using the given default answer when the user just presses Enter
+func (t *DefaultTerminal) AskForConfirmationWithDefault(msg ConfirmationMessage, defaultAnswer bool) bool {
+	if defaultAnswer {
+		return t.askForConfirmation(msg, "[Y/n]", "y")
+	}
+	return t.askForConfirmation(msg, "[y/N]", "n")
+}
+
+// AskForTypedConfirmation asks the user to confirm the given message by typing the expected value.
+// Any other answer is considered as a refusal.
+func (t *DefaultTerminal) AskForTypedConfirmation(msg ConfirmationMessage, expected string) bool {
+	t.printConfirmation(string(msg) + "\n")
+	t.printConfirmation("===============================\n")
+	t.printConfirmation(fmt.Sprintf("type '%s' to confirm -> ", expected))
 	if AssumeYes {
-		text = "y"
-	} else {
-		text, err = reader.ReadString('\n')
-		if err != nil {
-			log.Fatal("unable to read from input: ", err)
+		t.Printlnf("response: '%s'", expected)
+		t.printConfirmation("proceeding without confirmation (--assume-yes)\n")
+		return true
+	}
+	text, ok := t.readAnswer(bufio.NewReader(t.InOrStdin()))
+	if !ok {
+		return false
+	}
+	text = strings.TrimSpace(text)
+	t.Printlnf("response: '%s'", text)
+	if text != expected {
+		t.printConfirmation(fmt.Sprintf("the response does not match '%s', aborting\n", expected))
+		confirmationDeclined = true
+		return false
+	}
+	return true
+}
+
+func (t *DefaultTerminal) askForConfirmation(msg ConfirmationMessage, prompt, defaultAnswer string) bool {
+	reader := bufio.NewReader(t.InOrStdin())
+	t.printConfirmation(string(msg) + "\n")
+	t.printConfirmation("===============================\n")
+	t.printConfirmation(prompt + " -> ")
+	text := "y"
+	if !AssumeYes {
+		var ok bool
+		if text, ok = t.readAnswer(reader); !ok {
+			return false
 		}
 	}
 	text = strings.ReplaceAll(text, "\n", "")
+	if text == "" {
+		text = defaultAnswer
+	}
 	t.Printlnf("response: '%s'", text)
+	if AssumeYes {
+		t.printConfirmation("proceeding without confirmation (--assume-yes)\n")
+	}
 	switch text {
 	case "y", "Y":
 		return true
 	case "n", "N":
+		confirmationDeclined = true
 		return false
 	default:
-		return t.AskForConfirmation("answer y or n")
+		return t.askForConfirmation("answer y or n", prompt, defaultAnswer)
+	}
+}
+
+// readAnswer reads the answer of the user from the input, up to the end of the line.
+// If the standard input is not a terminal (eg, when ksctl runs in a pipeline or a scheduled job, where the input may stay open
+// without ever providing an answer) or if there is nothing to read (eg, the input is empty or closed), then the confirmation
+// is declined with a clear message instead of waiting for an answer which will never come, and false is returned.
+func (t *DefaultTerminal) readAnswer(reader *bufio.Reader) (string, bool) {
+	if in, ok := t.InOrStdin().(*os.File); ok && !term.IsTerminal(int(in.Fd())) {
+		t.declineWithoutAnswer("the input is not a terminal")
+		return "", false
+	}
+	text, err := reader.ReadString('\n')
+	if err == io.EOF && text != "" {
+		return text, true // the last line of the input has no line feed
+	}
+	if err != nil {
+		t.declineWithoutAnswer(err.Error())
+		return "", false
+	}
+	return text, true
+}
+
+// declineWithoutAnswer declines the confirmation for which no answer could be read, for the given reason
+func (t *DefaultTerminal) declineWithoutAnswer(reason string) {
+	t.Println("")
+	t.printConfirmation(fmt.Sprintf("confirmation required but no answer could be read from the input (%s), aborting - "+
+		"use the '--assume-yes' flag to run without confirmation\n", reason))
+	confirmationDeclined = true
+}
+
+// printConfirmation prints the given text of a confirmation, with the ConfirmationPrefix at the beginning of each line.
+// Note: the response of the user is printed on the same line as the prompt, so it is not prefixed again.
+func (t *DefaultTerminal) printConfirmation(text string) {
+	if ConfirmationPrefix != "" {
+		lines := strings.SplitAfter(text, "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = ConfirmationPrefix + line
+			}
+		}
+		text = strings.Join(lines, "")
 	}
+	fmt.Fprint(t.OutOrStdout(), text)
 }
@@ -2,13 +2,16 @@ package ioutils_test
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/kubesaw/ksctl/pkg/ioutils"
 	. "github.com/kubesaw/ksctl/pkg/test"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAskForConfirmationWhenAnswerIsY(t *testing.T) {
@@ -42,7 +45,7 @@ func TestAskForConfirmationWhenAssumeYesIsTrue(t *testing.T) {
 	assert.True(t, confirmation)
 	output := term.Output()
 	assert.Contains(t, output, "Are you sure that you want to do some action\n===============================\n[y/n] -> ")
-	assert.Contains(t, output, "[y/n] -> response: 'y'")
+	assert.Contains(t, output, "[y/n] -> response: 'y'\nproceeding without confirmation (--assume-yes)")
 	assert.NotContains(t, output, "!!!  DANGER ZONE  !!!")
 }
 
@@ -63,6 +66,125 @@ func TestAskForConfirmationWhenAnswerIsNWithDangerZone(t *testing.T) {
 	}
 }
 
+func TestAskForConfirmationWithDefault(t *testing.T) {
+	for _, defaultAnswer := range []bool{true, false} {
+		t.Run(fmt.Sprintf("default answer is %t", defaultAnswer), func(t *testing.T) {
+			expectedPrompt := "[y/N] -> "
+			if defaultAnswer {
+				expectedPrompt = "[Y/n] -> "
+			}
+
+			t.Run("empty answer takes the default", func(t *testing.T) {
+				// given
+				term := NewFakeTerminalWithResponse("")
+
+				// when
+				confirmation := term.AskForConfirmationWithDefault(ioutils.WithMessagef("do some %s", "action"), defaultAnswer)
+
+				// then
+				assert.Equal(t, defaultAnswer, confirmation)
+				assert.Contains(t, term.Output(), "Are you sure that you want to do some action\n===============================\n"+expectedPrompt)
+			})
+
+			t.Run("explicit answer takes precedence", func(t *testing.T) {
+				// given
+				answer := "y"
+				if defaultAnswer {
+					answer = "n"
+				}
+				term := NewFakeTerminalWithResponse(answer)
+
+				// when
+				confirmation := term.AskForConfirmationWithDefault(ioutils.WithMessagef("do some %s", "action"), defaultAnswer)
+
+				// then
+				assert.Equal(t, !defaultAnswer, confirmation)
+				assert.Contains(t, term.Output(), expectedPrompt+"response: '"+answer+"'")
+			})
+		})
+	}
+}
+
+func TestAskForTypedConfirmation(t *testing.T) {
+	t.Run("when the expected value is typed", func(t *testing.T) {
+		// given
+		term := NewFakeTerminalWithResponse("john")
+
+		// when
+		confirmation := term.AskForTypedConfirmation(ioutils.WithMessagef("delete %s", "john"), "john")
+
+		// then
+		assert.True(t, confirmation)
+		assert.Contains(t, term.Output(), "Are you sure that you want to delete john\n===============================\ntype 'john' to confirm -> response: 'john'")
+	})
+
+	for _, answer := range []string{"y", "", "jon"} {
+		t.Run("when '"+answer+"' is typed", func(t *testing.T) {
+			// given
+			term := NewFakeTerminalWithResponse(answer)
+
+			// when
+			confirmation := term.AskForTypedConfirmation(ioutils.WithMessagef("delete %s", "john"), "john")
+
+			// then
+			assert.False(t, confirmation)
+			assert.Contains(t, term.Output(), "the response does not match 'john', aborting")
+		})
+	}
+
+	t.Run("when AssumeYes is true", func(t *testing.T) {
+		// given
+		term := NewFakeTerminalWithResponse("n")
+		ioutils.AssumeYes = true
+		t.Cleanup(func() {
+			ioutils.AssumeYes = false
+		})
+
+		// when
+		confirmation := term.AskForTypedConfirmation(ioutils.WithMessagef("delete %s", "john"), "john")
+
+		// then
+		assert.True(t, confirmation)
+		assert.Contains(t, term.Output(), "proceeding without confirmation (--assume-yes)")
+	})
+}
+
+func TestAskForConfirmationWithPrefix(t *testing.T) {
+	// given
+	ioutils.ConfirmationPrefix = "[confirm] "
+	t.Cleanup(func() {
+		ioutils.ConfirmationPrefix = ""
+	})
+
+	t.Run("when answer is y", func(t *testing.T) {
+		// given
+		term := NewFakeTerminalWithResponse("y")
+
+		// when
+		confirmation := term.AskForConfirmation(ioutils.WithMessagef("do some %s", "action"))
+
+		// then
+		assert.True(t, confirmation)
+		assert.Equal(t, "[confirm] \n"+
+			"[confirm] Are you sure that you want to do some action\n"+
+			"[confirm] ===============================\n"+
+			"[confirm] [y/n] -> response: 'y'\n", term.Output())
+	})
+
+	t.Run("when typed answer does not match", func(t *testing.T) {
+		// given
+		term := NewFakeTerminalWithResponse("jon")
+
+		// when
+		confirmation := term.AskForTypedConfirmation(ioutils.WithMessagef("delete %s", "john"), "john")
+
+		// then
+		assert.False(t, confirmation)
+		assert.Contains(t, term.Output(), "[confirm] type 'john' to confirm -> response: 'jon'\n"+
+			"[confirm] the response does not match 'john', aborting\n")
+	})
+}
+
 func TestAskForConfirmationWhenFirstAnswerIsWrong(t *testing.T) {
 	// given
 	createTerm := func(correctAnswer string) ioutils.Terminal {
@@ -107,3 +229,103 @@ func TestAskForConfirmationWhenFirstAnswerIsWrong(t *testing.T) {
 		assert.False(t, confirmation)
 	})
 }
+
+func TestAskForConfirmationWithoutInput(t *testing.T) {
+	// given
+	// simulates ksctl running without a terminal, with an empty or closed input
+	createTerm := func(input string) (ioutils.Terminal, *bytes.Buffer) {
+		out := bytes.NewBuffer(nil)
+		return ioutils.NewTerminal(
+			func() io.Reader {
+				return bytes.NewBufferString(input)
+			},
+			func() io.Writer {
+				return out
+			},
+		), out
+	}
+	t.Cleanup(ioutils.ResetConfirmationDeclined)
+
+	t.Run("confirmation is declined", func(t *testing.T) {
+		// given
+		ioutils.ResetConfirmationDeclined()
+		term, out := createTerm("")
+
+		// when
+		confirmation := term.AskForConfirmationWithDefault(ioutils.WithMessagef("do some %s", "action"), true)
+
+		// then
+		assert.False(t, confirmation)
+		assert.True(t, ioutils.ConfirmationDeclined())
+		assert.Contains(t, out.String(), "confirmation required but no answer could be read from the input (EOF), aborting - "+
+			"use the '--assume-yes' flag to run without confirmation")
+	})
+
+	t.Run("typed confirmation is declined", func(t *testing.T) {
+		// given
+		ioutils.ResetConfirmationDeclined()
+		term, out := createTerm("")
+
+		// when
+		confirmation := term.AskForTypedConfirmation(ioutils.WithMessagef("delete %s", "john"), "john")
+
+		// then
+		assert.False(t, confirmation)
+		assert.True(t, ioutils.ConfirmationDeclined())
+		assert.Contains(t, out.String(), "confirmation required but no answer could be read from the input (EOF)")
+	})
+
+	t.Run("confirmation is declined when the input is not a terminal", func(t *testing.T) {
+		// given
+		ioutils.ResetConfirmationDeclined()
+		// the write end of the pipe stays open, so reading from it would block forever
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = r.Close()
+			_ = w.Close()
+		})
+		out := bytes.NewBuffer(nil)
+		term := ioutils.NewTerminal(
+			func() io.Reader {
+				return r
+			},
+			func() io.Writer {
+				return out
+			},
+		)
+
+		// when
+		confirmation := term.AskForConfirmation(ioutils.WithMessagef("do some %s", "action"))
+
+		// then
+		assert.False(t, confirmation)
+		assert.True(t, ioutils.ConfirmationDeclined())
+		assert.Contains(t, out.String(), "confirmation required but no answer could be read from the input (the input is not a terminal), aborting - "+
+			"use the '--assume-yes' flag to run without confirmation")
+	})
+
+	t.Run("answer without line feed is accepted", func(t *testing.T) {
+		// given
+		ioutils.ResetConfirmationDeclined()
+		term, out := createTerm("y")
+
+		// when
+		confirmation := term.AskForConfirmation(ioutils.WithMessagef("do some %s", "action"))
+
+		// then
+		assert.True(t, confirmation)
+		assert.NotContains(t, out.String(), "confirmation required")
+	})
+}
+
+func TestPrintNothingToDo(t *testing.T) {
+	// given
+	term := NewFakeTerminal()
+
+	// when
+	ioutils.PrintNothingToDo(term, "The Space '%s' is already in the '%s' tier", "john", "base")
+
+	// then
+	assert.Equal(t, "The Space 'john' is already in the 'base' tier: already in desired state; nothing to do\n", term.Output())
+}
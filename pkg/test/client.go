@@ -28,7 +28,7 @@ func NewFakeClients(t *testing.T, initObjs ...runtime.Object) (clicontext.NewCli
 		stringDataToData(obj)
 		return fakeClient.Client.Update(ctx, obj, opts...)
 	}
-	return func(token, apiEndpoint string) (runtimeclient.Client, error) {
+	return func(_ context.Context, token, apiEndpoint string) (runtimeclient.Client, error) {
 			t.Helper()
 			assert.Equal(t, "cool-token", token)
 			assert.Contains(t, apiEndpoint, "http")
@@ -26,6 +26,13 @@ func NoToken() ConfigOption {
 	}
 }
 
+// Token specifies the token to use (default is `cool-token`)
+func Token(token string) ConfigOption {
+	return func(content *ClusterDefinitionWithName) {
+		content.Token = token
+	}
+}
+
 // ServerAPI specifies the ServerAPI to use (default is `https://cool-server.com`)
 func ServerAPI(serverAPI string) ConfigOption {
 	return func(content *ClusterDefinitionWithName) {
@@ -47,6 +54,13 @@ func ServerName(serverName string) ConfigOption {
 	}
 }
 
+// OperatorNamespace specifies the namespace where the operator of the cluster is deployed
+func OperatorNamespace(namespace string) ConfigOption {
+	return func(content *ClusterDefinitionWithName) {
+		content.ClusterDefinition.OperatorNamespace = namespace
+	}
+}
+
 // ClusterType specifies the cluster type (`host` or `member`)
 func ClusterType(clusterType string) ConfigOption {
 	return func(content *ClusterDefinitionWithName) {
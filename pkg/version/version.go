@@ -1,6 +1,10 @@
 package version
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+)
 
 // we can't have those variables filled by the `-ldflags="-X ..."` in the `cmd/manager` package because
 // it's imported as `main`
@@ -10,8 +14,27 @@ var (
 	Commit = "unknown"
 	// BuildTime the time of build of the binary
 	BuildTime = "unknown"
+	// MinHostOperatorVersion the oldest version of the host operator this build of ksctl is known to be compatible with
+	MinHostOperatorVersion = "0.0.1"
 )
 
 func NewMessage() string {
 	return fmt.Sprintf("commit: '%s', build time: '%s'", Commit, BuildTime)
 }
+
+// CheckHostOperatorVersion returns an error if the given version of the host operator (as reported in the ToolchainStatus)
+// is older than MinHostOperatorVersion, or if it can't be parsed
+func CheckHostOperatorVersion(hostOperatorVersion string) error {
+	minVersion, err := semver.ParseTolerant(MinHostOperatorVersion)
+	if err != nil {
+		return fmt.Errorf("invalid minimum host operator version '%s': %w", MinHostOperatorVersion, err)
+	}
+	actual, err := semver.ParseTolerant(hostOperatorVersion)
+	if err != nil {
+		return fmt.Errorf("unknown host operator version '%s': %w", hostOperatorVersion, err)
+	}
+	if actual.LT(minVersion) {
+		return fmt.Errorf("ksctl requires the host operator version '%s' or later, but the host operator version is '%s'", MinHostOperatorVersion, hostOperatorVersion)
+	}
+	return nil
+}
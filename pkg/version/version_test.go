@@ -17,3 +17,26 @@ func TestVars(t *testing.T) {
 func TestVersionMessage(t *testing.T) {
 	assert.Equal(t, "commit: 'unknown', build time: 'unknown'", version.NewMessage())
 }
+
+func TestCheckHostOperatorVersion(t *testing.T) {
+	// given
+	version.MinHostOperatorVersion = "0.2.0"
+	t.Cleanup(func() {
+		version.MinHostOperatorVersion = "0.0.1"
+	})
+
+	t.Run("compatible", func(t *testing.T) {
+		for _, v := range []string{"0.2.0", "v0.2.1", "1.0.0"} {
+			assert.NoError(t, version.CheckHostOperatorVersion(v), v)
+		}
+	})
+
+	t.Run("too old", func(t *testing.T) {
+		assert.EqualError(t, version.CheckHostOperatorVersion("0.1.9"),
+			"ksctl requires the host operator version '0.2.0' or later, but the host operator version is '0.1.9'")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.ErrorContains(t, version.CheckHostOperatorVersion("latest"), "unknown host operator version 'latest'")
+	})
+}